@@ -0,0 +1,67 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/onewelcome/sdk-configurator/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	androidDir string
+	iosDir     string
+)
+
+func init() {
+	allCmd.Flags().StringVar(&androidDir, "android-dir", "", "Path to the Android project, relative to the application project root directory")
+	allCmd.Flags().StringVar(&iosDir, "ios-dir", "", "Path to the iOS project, relative to the application project root directory")
+}
+
+var allCmd = &cobra.Command{
+	Use:     "all",
+	Aliases: []string{"both"},
+	Short:   "Configure the Android and iOS projects of a single app at once",
+	Long: "Configure the Android and iOS projects of a single app at once, e.g. in a React Native or Flutter project. Provide both the " +
+		"module name (-m) and the target name (-t). The changes to both projects are only written when both platforms were configured " +
+		"successfully, so a failure on one platform does not leave the other one half-configured. When the Android and iOS projects are " +
+		"located in subdirectories of the application project, e.g. 'android' and 'ios', use --android-dir and --ios-dir. For a Flutter, " +
+		"React Native or Capacitor project use --flutter, --react-native or --capacitor instead, the module name and target name default to " +
+		"the ones that the framework generates.",
+	Run: func(cmd *cobra.Command, args []string) {
+		applyProjectConfigFile(cmd, "android", "ios")
+		verifyOutputFormat()
+		verifyIosLanguage()
+		if (isFlutter || isReactNative || isCapacitor) && (len(androidDir) > 0 || len(iosDir) > 0) {
+			exitOnError(fmt.Errorf("--android-dir and --ios-dir cannot be combined with --flutter, --react-native or --capacitor, the 'android' and 'ios' directories of the project are used"))
+		}
+		configureFlavors("all", func(config *util.Config) []util.Hint {
+			verifyConfig(config, "")
+			appRootDir := config.AppDir
+
+			config.AppDir = filepath.Join(appRootDir, androidDir)
+			configureAndroid(config)
+			androidHints := util.AndroidManifestUpdateHints(config)
+
+			config.AppDir = filepath.Join(appRootDir, iosDir)
+			configureIos(config)
+			iosHints := util.IosInfoPlistUpdateHints(config)
+
+			return append(androidHints, iosHints...)
+		})
+	},
+}
@@ -27,31 +27,62 @@ var androidCmd = &cobra.Command{
 	Use:   "android",
 	Short: "Configure an Android project",
 	Run: func(cmd *cobra.Command, args []string) {
-		config := util.ParseConfig(appDir, tsConfigLocation)
+		applyProjectConfigFile(cmd, "android")
+		verifyOutputFormat()
+		configureFlavors("android", func(config *util.Config) []util.Hint {
+			verifyConfig(config, "android")
+			configureAndroid(config)
+			return util.AndroidManifestUpdateHints(config)
+		})
+	},
+}
 
-		verifyAppModuleName(moduleName)
-		util.SetAppTarget(moduleName, config)
-		util.SetFlavorName(flavorName, config)
+// configureAndroid stages all changes to the Android project in the config
+func configureAndroid(config *util.Config) {
+	appModuleName := moduleName
+	if (isFlutter || isReactNative || isCapacitor) && len(appModuleName) == 0 {
+		appModuleName = defaultAndroidModuleName
+	}
+	verifyAppModuleName(config, appModuleName)
+	util.SetAppTarget(appModuleName, config)
+	util.SetBuildType(buildType, config)
+	util.SetTemplateDir(templateDir, config)
+	util.SetManifestUpdate(updateManifest, manifestActivity, config)
+	util.SetAssetLinksCertificate(assetLinksCert, assetLinksPassword, assetLinksKeyAlias, config)
 
-		if isCordova {
-			config.ConfigureForCordova = true
-			util.ParseCordovaConfig(config)
-			verifyAndroidPlatformInstalled("ERROR: Your project does not seem to have the Android platform added. Please try `cordova platform add android`")
-		} else if isNativeScript {
-			config.ConfigureForNativeScript = true
-			util.SetAppTarget("", config)
-			util.ParseNativeScriptConfig(config)
-			verifyAndroidPlatformInstalled("ERROR: Your project does not seem to have the Android platform added. Please try `tns platform add android`")
-		}
-		util.ParseAndroidManifest(config)
-		util.PrepareAndroidPaths(config)
-		util.WriteAndroidAppScheme(config)
-		util.CreateKeystore(config)
-		util.WriteAndroidConfigModel(config, generateJavaConfigModel)
-		util.RemoveAndroidSecurityController(config)
-		util.PrintSuccessMessage(config)
-		util.PrintAndroidManifestUpdateHint(config)
-	},
+	if isCordova {
+		config.ConfigureForCordova = true
+		exitOnError(util.ParseCordovaConfig(config))
+		verifyAndroidPlatformInstalled("ERROR: Your project does not seem to have the Android platform added. Please try `cordova platform add android`")
+	} else if isNativeScript {
+		config.ConfigureForNativeScript = true
+		util.SetAppTarget("", config)
+		exitOnError(util.ParseNativeScriptConfig(config))
+		verifyAndroidPlatformInstalled("ERROR: Your project does not seem to have the Android platform added. Please try `tns platform add android`")
+	} else if isFlutter {
+		config.ConfigureForFlutter = true
+		exitOnError(util.VerifyFlutterProject(config))
+		verifyPlatformDirExists(config, "android", "ERROR: Your project does not seem to have the Android platform added. Please try `flutter create --platforms=android .`")
+	} else if isReactNative {
+		config.ConfigureForReactNative = true
+		exitOnError(util.ParseReactNativeConfig(config))
+		verifyPlatformDirExists(config, "android", "ERROR: Your project does not seem to have an 'android' directory. For an Expo project please try `npx expo prebuild`")
+	} else if isCapacitor {
+		config.ConfigureForCapacitor = true
+		exitOnError(util.ParseCapacitorConfig(config))
+		verifyPlatformDirExists(config, "android", "ERROR: Your project does not seem to have the Android platform added. Please try `npx cap add android`")
+	}
+	if updateManifest && (isCordova || isNativeScript || isCapacitor) {
+		config.AddWarning("Ignoring the update manifest parameter, the manifest of a Cordova, NativeScript or Capacitor project is configured by the plugin")
+	}
+	exitOnError(util.ValidateAndroidVariant(config))
+	exitOnError(util.ParseAndroidManifest(config))
+	util.PrepareAndroidPaths(config, generateJavaConfigModel)
+	exitOnError(util.WriteAndroidAppScheme(config))
+	exitOnError(util.CreateAssetLinks(config))
+	exitOnError(util.CreateKeystore(config))
+	exitOnError(util.WriteAndroidConfigModel(config, generateJavaConfigModel))
+	util.RemoveAndroidSecurityController(config)
 }
 
 func verifyAndroidPlatformInstalled(errorMessage string) {
@@ -62,10 +93,19 @@ func verifyAndroidPlatformInstalled(errorMessage string) {
 	}
 }
 
-func verifyAppModuleName(moduleName string) {
+// verifyPlatformDirExists verifies that the project of a cross-platform framework, e.g. Flutter, contains the directory of the platform
+func verifyPlatformDirExists(config *util.Config, platformDir string, errorMessage string) {
+	_, err := os.Stat(path.Join(config.AppDir, platformDir))
+	if os.IsNotExist(err) {
+		_, _ = os.Stderr.WriteString(fmt.Sprintln(errorMessage))
+		os.Exit(1)
+	}
+}
+
+func verifyAppModuleName(config *util.Config, moduleName string) {
 	if isCordova || isNativeScript {
 		if len(moduleName) != 0 {
-			fmt.Println("WARNING: Ignoring the module name parameter for Cordova or NativeScript")
+			config.AddWarning("Ignoring the module name parameter for Cordova or NativeScript")
 		}
 	} else {
 		if len(moduleName) == 0 {
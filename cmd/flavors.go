@@ -0,0 +1,94 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onewelcome/sdk-configurator/util"
+)
+
+type flavorConfig struct {
+	name       string
+	configPath string
+}
+
+var (
+	// projectFlavorConfigPaths contains the config zips of the flavors in the project config file, they are used for a --flavor without a config zip
+	projectFlavorConfigPaths = make(map[string]string)
+	// configuringFlavor is the flavor that is being configured when several flavors are configured in one run
+	configuringFlavor string
+)
+
+// parseFlavorConfigs returns the flavors that are configured in this run. Without --flavor that is only the flavor given with -f, which may be
+// empty, configured with the config zip given with -c.
+func parseFlavorConfigs() ([]flavorConfig, error) {
+	if len(flavorConfigs) == 0 {
+		return []flavorConfig{{name: flavorName, configPath: tsConfigLocation}}, nil
+	}
+	if len(flavorName) > 0 {
+		return nil, fmt.Errorf("use either --flavor-name or --flavor, not both")
+	}
+
+	var flavors []flavorConfig
+	names := make(map[string]bool)
+	for _, value := range flavorConfigs {
+		name, configPath, hasConfigPath := strings.Cut(value, "=")
+		if len(name) == 0 {
+			return nil, fmt.Errorf("invalid flavor '%v', use --flavor <flavor-name>=<config-zip>", value)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("the '%v' flavor is given more than once", name)
+		}
+		names[name] = true
+
+		if !hasConfigPath {
+			configPath = projectFlavorConfigPaths[name]
+		}
+		if len(configPath) == 0 {
+			configPath = tsConfigLocation
+		}
+		flavors = append(flavors, flavorConfig{name: name, configPath: configPath})
+	}
+	return flavors, nil
+}
+
+// configureFlavors configures every flavor of this run with its own config zip. The changes of all flavors are staged in a single change set,
+// so nothing is written to the project when one of the flavors cannot be configured.
+func configureFlavors(platform string, configure func(config *util.Config) []util.Hint) {
+	flavors, err := parseFlavorConfigs()
+	exitOnError(err)
+
+	var configs []*util.Config
+	var hints [][]util.Hint
+	for _, flavor := range flavors {
+		if len(flavors) > 1 {
+			configuringFlavor = flavor.name
+		}
+		config, err := util.ParseConfig(appDir, flavor.configPath)
+		exitOnError(err)
+		if len(configs) > 0 {
+			config.ShareChanges(configs[0])
+		}
+		util.SetFlavorName(flavor.name, config)
+
+		hints = append(hints, configure(config))
+		configs = append(configs, config)
+	}
+	configuringFlavor = ""
+
+	finishConfiguration(configs, platform, hints)
+}
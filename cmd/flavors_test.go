@@ -0,0 +1,136 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/onewelcome/sdk-configurator/util"
+)
+
+// setFlavorTestFlags sets the flags that select the flavors of a run and restores them when the test is done.
+func setFlavorTestFlags(t *testing.T, flavors []string, name string, configPath string, projectConfigPaths map[string]string) {
+	previousFlavors, previousName, previousConfigPath, previousProjectConfigPaths := flavorConfigs, flavorName, tsConfigLocation, projectFlavorConfigPaths
+	t.Cleanup(func() {
+		flavorConfigs, flavorName, tsConfigLocation, projectFlavorConfigPaths = previousFlavors, previousName, previousConfigPath, previousProjectConfigPaths
+	})
+	flavorConfigs, flavorName, tsConfigLocation, projectFlavorConfigPaths = flavors, name, configPath, projectConfigPaths
+}
+
+// writeTestConfigZip writes a configuration zip with the redirect URL, it only contains what is needed to parse it.
+func writeTestConfigZip(t *testing.T, dir string, name string, redirectUrl string) string {
+	zipPath := filepath.Join(dir, name)
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer zipFile.Close()
+
+	zipWriter := zip.NewWriter(zipFile)
+	files := map[string]string{
+		"config.json":           `{"application_identifier": "ExampleApp", "redirect_url": "` + redirectUrl + `", "resource_gateway_uri": ["https://rg.example.com"]}`,
+		"certificates/root.cer": "certificate",
+	}
+	for fileName, contents := range files {
+		writer, _ := zipWriter.Create(fileName)
+		_, _ = writer.Write([]byte(contents))
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return zipPath
+}
+
+func TestParseFlavorConfigs(t *testing.T) {
+	testCases := []struct {
+		description        string
+		flavors            []string
+		flavorName         string
+		projectConfigPaths map[string]string
+		expected           []flavorConfig
+		expectError        bool
+	}{
+		{
+			description: "no flavors",
+			expected:    []flavorConfig{{name: "", configPath: "default.zip"}},
+		},
+		{
+			description: "the flavor name",
+			flavorName:  "dev",
+			expected:    []flavorConfig{{name: "dev", configPath: "default.zip"}},
+		},
+		{
+			description:        "flavors with and without a config zip",
+			flavors:            []string{"dev=dev.zip", "prod", "demo"},
+			projectConfigPaths: map[string]string{"prod": "project-prod.zip"},
+			expected: []flavorConfig{
+				{name: "dev", configPath: "dev.zip"},
+				{name: "prod", configPath: "project-prod.zip"},
+				{name: "demo", configPath: "default.zip"},
+			},
+		},
+		{description: "flavors and the flavor name", flavors: []string{"dev=dev.zip"}, flavorName: "prod", expectError: true},
+		{description: "a flavor without a name", flavors: []string{"=dev.zip"}, expectError: true},
+		{description: "a flavor given twice", flavors: []string{"dev=dev.zip", "dev=other.zip"}, expectError: true},
+	}
+	for _, testCase := range testCases {
+		setFlavorTestFlags(t, testCase.flavors, testCase.flavorName, "default.zip", testCase.projectConfigPaths)
+
+		flavors, err := parseFlavorConfigs()
+		if testCase.expectError {
+			if err == nil {
+				t.Errorf("Incorrect result for %v, expected an error but got %v", testCase.description, flavors)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %v: %v", testCase.description, err)
+		} else if !reflect.DeepEqual(flavors, testCase.expected) {
+			t.Errorf("Incorrect result for %v, expected %v but got %v", testCase.description, testCase.expected, flavors)
+		}
+	}
+}
+
+func TestConfigureFlavors(t *testing.T) {
+	zipDir := t.TempDir()
+	devZip := writeTestConfigZip(t, zipDir, "dev.zip", "dev://loginsuccess")
+	prodZip := writeTestConfigZip(t, zipDir, "prod.zip", "prod://loginsuccess")
+	setFlavorTestFlags(t, []string{"dev=" + devZip, "prod=" + prodZip}, "", "", map[string]string{})
+
+	previousAppDir, previousDryRun, previousOutputFormat := appDir, dryRun, outputFormat
+	t.Cleanup(func() { appDir, dryRun, outputFormat = previousAppDir, previousDryRun, previousOutputFormat })
+	appDir, dryRun, outputFormat = t.TempDir(), true, outputText
+
+	var configured []string
+	configureFlavors("ios", func(config *util.Config) []util.Hint {
+		if configuringFlavor != config.FlavorName {
+			t.Errorf("Incorrect result, configuring flavor %v for the %v flavor", configuringFlavor, config.FlavorName)
+		}
+		configured = append(configured, config.FlavorName+" "+config.Options.RedirectUrl)
+		return nil
+	})
+
+	if expected := []string{"dev dev://loginsuccess", "prod prod://loginsuccess"}; !reflect.DeepEqual(configured, expected) {
+		t.Errorf("Incorrect result, expected the flavors %v but got %v", expected, configured)
+	}
+	if len(configuringFlavor) > 0 {
+		t.Errorf("Incorrect result, the configuring flavor %v is not reset", configuringFlavor)
+	}
+
+}
@@ -0,0 +1,40 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/onewelcome/sdk-configurator/util"
+	"github.com/spf13/cobra"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Print the contents of a Token Server config zip file without configuring a project",
+	Run: func(cmd *cobra.Command, args []string) {
+		verifyOutputFormat()
+
+		config, err := util.ReadTsZip(tsConfigLocation)
+		exitOnError(err)
+
+		inspection := util.InspectConfig(config, time.Duration(certExpiryWarningDays)*24*time.Hour)
+		if outputFormat == outputJson {
+			exitOnError(util.PrintJson(inspection))
+			return
+		}
+		util.PrintInspection(inspection)
+	},
+}
@@ -29,34 +29,75 @@ var iosCmd = &cobra.Command{
 	Short: "Configure an iOS project",
 	Long:  "",
 	Run: func(cmd *cobra.Command, args []string) {
-		config := util.ParseConfig(appDir, tsConfigLocation)
-		var appTarget string
+		applyProjectConfigFile(cmd, "ios")
+		verifyOutputFormat()
+		verifyIosLanguage()
+		configureFlavors("ios", func(config *util.Config) []util.Hint {
+			verifyConfig(config, "ios")
+			configureIos(config)
+			return util.IosInfoPlistUpdateHints(config)
+		})
+	},
+}
 
-		if isCordova {
-			config.ConfigureForCordova = true
-			util.ParseCordovaConfig(config)
-			appTarget = config.Cordova.AppName
+// configureIos stages all changes to the iOS project in the config
+func configureIos(config *util.Config) {
+	var appTarget string
 
-			verifyIosPlatformInstalled("ERROR: Your project does not seem to have the iOS platform added. Please try `cordova platform add ios`")
-		} else if isNativeScript {
-			config.ConfigureForNativeScript = true
-			util.ParseNativeScriptConfig(config)
-			appTarget = targetName
+	if isCordova {
+		config.ConfigureForCordova = true
+		exitOnError(util.ParseCordovaConfig(config))
+		appTarget = config.Cordova.AppName
 
-			verifyIosPlatformInstalled("ERROR: Your project does not seem to have the iOS platform added. Please try `tns platform add ios`")
-		} else {
-			appTarget = targetName
+		verifyIosPlatformInstalled("ERROR: Your project does not seem to have the iOS platform added. Please try `cordova platform add ios`")
+	} else if isNativeScript {
+		config.ConfigureForNativeScript = true
+		exitOnError(util.ParseNativeScriptConfig(config))
+		appTarget = targetName
+
+		verifyIosPlatformInstalled("ERROR: Your project does not seem to have the iOS platform added. Please try `tns platform add ios`")
+	} else if isFlutter {
+		config.ConfigureForFlutter = true
+		exitOnError(util.VerifyFlutterProject(config))
+		appTarget = targetName
+		if len(appTarget) == 0 {
+			appTarget = flutterIosTargetName
 		}
-		verifyAppTarget(appTarget)
-		util.SetAppTarget(appTarget, config)
-		util.SetFlavorName(flavorName, config)
-		util.PrepareIosPaths(config)
-		util.WriteIOSConfigModel(config)
-		util.ConfigureIOSCertificates(config)
-		util.RemoveIOSSecurityController(config)
-		util.PrintSuccessMessage(config)
-		util.PrintIosInfoPlistUpdateHint(config)
-	},
+
+		verifyPlatformDirExists(config, "ios", "ERROR: Your project does not seem to have the iOS platform added. Please try `flutter create --platforms=ios .`")
+	} else if isReactNative {
+		config.ConfigureForReactNative = true
+		exitOnError(util.ParseReactNativeConfig(config))
+		appTarget = targetName
+		if len(appTarget) == 0 {
+			appTarget = config.ReactNative.Name
+		}
+
+		verifyPlatformDirExists(config, "ios", "ERROR: Your project does not seem to have an 'ios' directory. For an Expo project please try `npx expo prebuild`")
+	} else if isCapacitor {
+		config.ConfigureForCapacitor = true
+		exitOnError(util.ParseCapacitorConfig(config))
+		appTarget = targetName
+		if len(appTarget) == 0 {
+			appTarget = capacitorIosTargetName
+		}
+
+		verifyPlatformDirExists(config, "ios", "ERROR: Your project does not seem to have the iOS platform added. Please try `npx cap add ios`")
+	} else {
+		appTarget = targetName
+	}
+	verifyAppTarget(appTarget)
+	util.SetAppTarget(appTarget, config)
+	util.SetTemplateDir(templateDir, config)
+	util.SetInfoPlistUpdate(updateInfoPlist, config)
+	util.PrepareIosPaths(config)
+	exitOnError(util.WriteIOSConfigModel(config, iosLanguage == iosLanguageSwift))
+	exitOnError(util.ConfigureIOSCertificates(config))
+	exitOnError(util.RemoveIOSSecurityController(config))
+	if updateInfoPlist && isCordova {
+		config.AddWarning("Ignoring the update Info.plist parameter, the Info.plist of a Cordova project is configured by the plugin")
+	}
+	exitOnError(util.UpdateIosInfoPlist(config))
 }
 
 func verifyAppTarget(appTarget string) {
@@ -73,6 +114,12 @@ func verifyAppTarget(appTarget string) {
 	}
 }
 
+func verifyIosLanguage() {
+	if iosLanguage != iosLanguageObjc && iosLanguage != iosLanguageSwift {
+		exitOnError(fmt.Errorf("unsupported iOS language '%v', use either '%v' or '%v'", iosLanguage, iosLanguageObjc, iosLanguageSwift))
+	}
+}
+
 func verifyIosPlatformInstalled(errorMessage string) {
 	_, err := os.Stat(path.Join(appDir, "platforms", "ios"))
 	if os.IsNotExist(err) {
@@ -14,7 +14,15 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onewelcome/sdk-configurator/util"
+	"github.com/spf13/cobra"
+)
 
 var (
 	tsConfigLocation        string
@@ -22,26 +30,158 @@ var (
 	targetName              string
 	moduleName              string
 	flavorName              string
+	buildType               string
 	generateJavaConfigModel bool
 	isCordova               bool
 	isNativeScript          bool
+	isFlutter               bool
+	isReactNative           bool
+	isCapacitor             bool
+	updateManifest          bool
+	manifestActivity        string
+	updateInfoPlist         bool
+	assetLinksCert          string
+	assetLinksPassword      string
+	assetLinksKeyAlias      string
+	dryRun                  bool
+	outputFormat            string
+	certExpiryWarningDays   int
+	iosLanguage             string
+	templateDir             string
+	flavorConfigs           []string
+)
+
+const (
+	outputText = "text"
+	outputJson = "json"
+
+	iosLanguageObjc  = "objc"
+	iosLanguageSwift = "swift"
+
+	// the Android module that Flutter, React Native and Capacitor generate and the iOS targets that Flutter and Capacitor generate, React
+	// Native names the iOS target after the app
+	defaultAndroidModuleName = "app"
+	flutterIosTargetName     = "Runner"
+	capacitorIosTargetName   = "App"
 )
 
 func init() {
 	RootCmd.AddCommand(androidCmd)
 	RootCmd.AddCommand(iosCmd)
+	RootCmd.AddCommand(allCmd)
+	RootCmd.AddCommand(inspectCmd)
 	RootCmd.AddCommand(versionCmd)
 	RootCmd.PersistentFlags().StringVarP(&tsConfigLocation, "config", "c", "", "Path to Token Server config zip file")
 	RootCmd.PersistentFlags().StringVarP(&appDir, "app-dir", "a", ".", "Path to application project root directory")
 	RootCmd.PersistentFlags().StringVarP(&targetName, "target-name", "t", "", "The target name in your Xcode project for which you want to configure the SDK (for iOS). More info can be found at https://developer.apple.com/library/ios/documentation/IDEs/Conceptual/AppDistributionGuide/ConfiguringYourApp/ConfiguringYourApp.html")
 	RootCmd.PersistentFlags().StringVarP(&moduleName, "module-name", "m", "", "The Gradle module name that contains your application sources (for Android). More info can be found at https://developer.android.com/studio/projects/index.html")
-	RootCmd.PersistentFlags().StringVarP(&flavorName, "flavor-name", "f", "", "The optional flavor name for Android project (or destination subfolder for iOS). More info can be found at https://developer.android.com/studio/build/build-variants#product-flavors")
+	RootCmd.PersistentFlags().StringVarP(&flavorName, "flavor-name", "f", "", "The optional flavor name for Android project, with several flavor dimensions the flavors combined as in the build variant name, e.g. freeStaging (or destination subfolder for iOS). More info can be found at https://developer.android.com/studio/build/build-variants#product-flavors")
+	RootCmd.PersistentFlags().StringArrayVar(&flavorConfigs, "flavor", nil, "Configure several flavors in one run, given as <flavor-name>=<config-zip>. Repeat the flag for every flavor")
+	RootCmd.PersistentFlags().StringVar(&buildType, "build-type", "", "The optional build type for Android project, the keystore and config model are generated in the source set of the flavor and build type (e.g. src/freeStagingDebug)")
 	RootCmd.PersistentFlags().BoolVarP(&generateJavaConfigModel, "generateJavaConfigModel", "g", false, "Generate OneginiConfigModel in Java instead of Kotlin")
+	RootCmd.PersistentFlags().BoolVar(&updateManifest, "update-manifest", false, "Add the intent-filter for the redirect URL to the AndroidManifest.xml (for Android)")
+	RootCmd.PersistentFlags().StringVar(&manifestActivity, "manifest-activity", "", "The activity that handles the redirect URL when using --update-manifest, defaults to the launcher activity")
+	RootCmd.PersistentFlags().StringVar(&assetLinksCert, "assetlinks-cert", "", "The release keystore (JKS or PKCS12), a signed APK, the signing certificate (PEM or DER) or its SHA-256 fingerprint, used to print the Digital Asset Links file for an https redirect URL (for Android)")
+	RootCmd.PersistentFlags().StringVar(&assetLinksPassword, "assetlinks-keystore-password", "", "The password of the keystore given with --assetlinks-cert")
+	RootCmd.PersistentFlags().StringVar(&assetLinksKeyAlias, "assetlinks-key-alias", "", "The alias of the signing key when the JKS keystore given with --assetlinks-cert contains several keys")
+	RootCmd.PersistentFlags().BoolVar(&updateInfoPlist, "update-info-plist", false, "Add the scheme of the redirect URL to the Info.plist, or the associated domain for an https redirect URL to the entitlements (for iOS)")
+	RootCmd.PersistentFlags().StringVar(&iosLanguage, "ios-language", iosLanguageObjc, "Generate OneginiConfigModel in Objective-C ('objc') or Swift ('swift') (for iOS)")
+	RootCmd.PersistentFlags().StringVar(&templateDir, "template-dir", "", "Directory with config model templates (e.g. OneginiConfigModel.kt) that override the bundled templates")
 	RootCmd.PersistentFlags().BoolVarP(&isCordova, "cordova", "o", false, "Configure as Cordova project")
 	RootCmd.PersistentFlags().BoolVarP(&isNativeScript, "nativescript", "n", false, "Configure as NativeScript project")
+	RootCmd.PersistentFlags().BoolVar(&isFlutter, "flutter", false, "Configure as Flutter project, the module name defaults to 'app' and the target name to 'Runner'")
+	RootCmd.PersistentFlags().BoolVar(&isReactNative, "react-native", false, "Configure as React Native project, the module name defaults to 'app' and the target name to the app name in app.json")
+	RootCmd.PersistentFlags().BoolVar(&isCapacitor, "capacitor", false, "Configure as Capacitor (Ionic) project, the module name defaults to 'app' and the target name to 'App'")
+	RootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the changes that would be made to the project without writing them")
+	RootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "The output format of the configuration summary, either 'text' or 'json'")
+	RootCmd.PersistentFlags().IntVar(&certExpiryWarningDays, "cert-expiry-warning-days", 30, "Warn about certificates in the config zip file that expire within this number of days")
 	_ = RootCmd.PersistentFlags().MarkHidden("tamperingProtection")
 }
 
+// errorHints contains additional guidance that is printed when a command fails with one of these errors
+var errorHints = []struct {
+	err  error
+	hint string
+}{
+	{util.ErrMissingConfigZip, "Provide one using 'sdk-configurator <platform> -c <config-zip-location>'\n\nexecute 'sdk-configurator --help' to see how to use the configurator"},
+	{util.ErrInvalidConfigZip, "Is the supplied archive a valid Token Server configuration zip?"},
+	{util.ErrMissingResourceGateway, "Please check the Token Server configuration.\nSee the following link for more info: https://docs.onegini.com/public/token-server/topics/general-app-config/resource-gateway/resource-gateway.html"},
+	{util.ErrInvalidOption, "Please check the application configuration in the Token Server admin panel and download a new configuration zip"},
+	{util.ErrExpiredCertificate, "Pinning an expired certificate makes the app unable to connect. Please check the certificates in the Token Server configuration"},
+	{util.ErrActivityNotFound, "Use --manifest-activity to select the activity that handles the redirect URL"},
+	{util.ErrInfoPlistNotFound, "Set the INFOPLIST_FILE build setting of the target in Xcode or leave out --update-info-plist and add the scheme by hand"},
+	{util.ErrInvalidSigningCertificate, "Provide the keystore that the release build is signed with and its password, using --assetlinks-key-alias when it contains several keys, or a signed APK using --assetlinks-cert"},
+	{util.ErrInvalidBuildVariant, "Use --flavor-name with a flavor of the module, or with one flavor of every flavor dimension combined as in the name of the build variant (e.g. freeStaging), and --build-type with a build type of the module"},
+	{util.ErrInvalidCertificate, "Make sure that it is a PEM encoded certificate. All cert files should start with '-----BEGIN CERTIFICATE-----'"},
+}
+
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+
+	if len(configuringFlavor) > 0 {
+		err = fmt.Errorf("cannot configure the '%v' flavor: %w", configuringFlavor, err)
+	}
+	_, _ = os.Stderr.WriteString(fmt.Sprintf("ERROR: %v\n", err))
+	for _, errorHint := range errorHints {
+		if errors.Is(err, errorHint.err) {
+			_, _ = os.Stderr.WriteString(fmt.Sprintf("ERROR: %v\n", errorHint.hint))
+		}
+	}
+	os.Exit(1)
+}
+
+func verifyConfig(config *util.Config, platform string) {
+	exitOnError(util.ValidateOptions(config, platform))
+	exitOnError(util.ValidateCertificates(config, time.Duration(certExpiryWarningDays)*24*time.Hour))
+}
+
+func verifyOutputFormat() {
+	if outputFormat != outputText && outputFormat != outputJson {
+		exitOnError(fmt.Errorf("unsupported output format '%v', use either '%v' or '%v'", outputFormat, outputText, outputJson))
+	}
+}
+
+// finishConfiguration applies the staged changes, unless running with --dry-run, and prints the summary in the requested output format. The
+// configs share their change set when several flavors are configured, the hints are the hints of every config.
+func finishConfiguration(configs []*util.Config, platform string, hints [][]util.Hint) {
+	if !dryRun {
+		exitOnError(util.ApplyChanges(configs[0]))
+	}
+
+	if outputFormat == outputJson {
+		var reports []*util.Report
+		for i, config := range configs {
+			report, err := util.NewReport(config, platform, hints[i], dryRun)
+			exitOnError(err)
+			reports = append(reports, report)
+		}
+		if len(reports) == 1 {
+			exitOnError(util.PrintJson(reports[0]))
+		} else {
+			exitOnError(util.PrintJson(util.FlavorsReport{Platform: platform, DryRun: dryRun, Flavors: reports}))
+		}
+		return
+	}
+
+	if dryRun {
+		util.PrintChangePlan(configs[0])
+		for _, config := range configs {
+			util.PrintAssetLinks(config)
+		}
+		return
+	}
+	for i, config := range configs {
+		if i > 0 {
+			fmt.Println("")
+		}
+		util.PrintSuccessMessage(config)
+		util.PrintHints(hints[i])
+		util.PrintAssetLinks(config)
+	}
+}
+
 var RootCmd = &cobra.Command{
 	Use:   "sdk-configurator [platform]",
 	Short: "Configure your mobile SDK",
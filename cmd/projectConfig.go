@@ -0,0 +1,205 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const projectConfigFileName = ".sdk-configurator.json"
+
+// projectConfigPathFlags contains the flags of which the values in the project config file are paths relative to the app dir
+var projectConfigPathFlags = map[string]bool{"config": true, "template-dir": true}
+
+// applyProjectConfigFile sets the flags that are not given on the command line to the values in the .sdk-configurator.json file in the app
+// dir. The keys in the file are flag names. The top level settings apply to every command, the settings in the "android" and "ios" sections
+// only when configuring that platform and the settings in the "flavors" section only when configuring that flavor. A more specific section
+// overrides a more general one and flags that are given on the command line always take precedence over the file. The config zips in the
+// "flavors" section are used as well when several flavors are configured with --flavor.
+func applyProjectConfigFile(cmd *cobra.Command, platforms ...string) {
+	exitOnError(loadProjectConfigFile(cmd, platforms...))
+}
+
+func loadProjectConfigFile(cmd *cobra.Command, platforms ...string) error {
+	projectConfigPath := filepath.Join(appDir, projectConfigFileName)
+	contents, err := ioutil.ReadFile(projectConfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var projectConfig map[string]interface{}
+	if err := json.Unmarshal(contents, &projectConfig); err != nil {
+		return fmt.Errorf("cannot read %v: %w", projectConfigPath, err)
+	}
+	settings, err := mergeProjectConfigSections(cmd, projectConfigPath, projectConfig, platforms)
+	if err != nil {
+		return err
+	}
+	return applyProjectConfigSettings(cmd, projectConfigPath, settings)
+}
+
+// mergeProjectConfigSections merges the sections of the project config file that apply to the command into a single set of settings, the
+// settings of a platform override the top level settings and the settings of the flavor override both. The all command configures both
+// platforms with the same flags, so the platform sections may only set a shared flag to the same value.
+func mergeProjectConfigSections(cmd *cobra.Command, projectConfigPath string, projectConfig map[string]interface{}, platforms []string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	for key, value := range projectConfig {
+		if key != "android" && key != "ios" && key != "flavors" {
+			settings[key] = value
+		}
+	}
+
+	platformSettings := make(map[string]interface{})
+	platformSectionNames := make(map[string]string)
+	for _, platform := range platforms {
+		section, err := projectConfigSection(projectConfigPath, projectConfig, platform)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range sortedSettingNames(section) {
+			if otherPlatform, found := platformSectionNames[name]; found && !reflect.DeepEqual(platformSettings[name], section[name]) {
+				return nil, fmt.Errorf("cannot read %v: '%v' has a different value in the '%v' and '%v' sections, the %v command configures both "+
+					"platforms with the same value", projectConfigPath, name, otherPlatform, platform, cmd.Name())
+			}
+			platformSettings[name] = section[name]
+			platformSectionNames[name] = platform
+		}
+	}
+	for name, value := range platformSettings {
+		settings[name] = value
+	}
+
+	flavors, err := projectConfigSection(projectConfigPath, projectConfig, "flavors")
+	if err != nil {
+		return nil, err
+	}
+	flavorSections := make(map[string]map[string]interface{})
+	for _, name := range sortedSettingNames(flavors) {
+		flavorSettings, err := projectConfigSection(projectConfigPath, flavors, name)
+		if err != nil {
+			return nil, err
+		}
+		flavorSections[name] = flavorSettings
+		if configPath, ok := flavorSettings["config"].(string); ok {
+			projectFlavorConfigPaths[name] = resolveProjectConfigPath(configPath)
+		}
+	}
+
+	// the flavor name may be set by the sections above
+	configuredFlavorName, _ := settings["flavor-name"].(string)
+	if cmd.Flags().Changed("flavor-name") {
+		configuredFlavorName, _ = cmd.Flags().GetString("flavor-name")
+	}
+	if flavorSettings, ok := flavorSections[configuredFlavorName]; ok && len(configuredFlavorName) > 0 {
+		for name, value := range flavorSettings {
+			settings[name] = value
+		}
+	}
+	return settings, nil
+}
+
+func projectConfigSection(projectConfigPath string, projectConfig map[string]interface{}, name string) (map[string]interface{}, error) {
+	section, found := projectConfig[name]
+	if !found {
+		return nil, nil
+	}
+	settings, ok := section.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot read %v: '%v' should be an object", projectConfigPath, name)
+	}
+	return settings, nil
+}
+
+// applyProjectConfigSettings sets every flag in the settings once, in the order of their names, unless it is given on the command line.
+func applyProjectConfigSettings(cmd *cobra.Command, projectConfigPath string, settings map[string]interface{}) error {
+	for _, name := range sortedSettingNames(settings) {
+		if name == "app-dir" {
+			return fmt.Errorf("cannot read %v: 'app-dir' cannot be set in the project config file, since the file is read from the app dir", projectConfigPath)
+		}
+		if cmd.Flags().Lookup(name) == nil {
+			return fmt.Errorf("cannot read %v: '%v' is not a supported setting for the %v command", projectConfigPath, name, cmd.Name())
+		}
+		if cmd.Flags().Changed(name) {
+			continue
+		}
+
+		values, err := projectConfigValues(settings[name])
+		if err != nil {
+			return fmt.Errorf("cannot read %v: invalid value for '%v': %w", projectConfigPath, name, err)
+		}
+		for _, flagValue := range values {
+			if projectConfigPathFlags[name] {
+				flagValue = resolveProjectConfigPath(flagValue)
+			} else if flavor, configPath, hasConfigPath := strings.Cut(flagValue, "="); name == "flavor" && hasConfigPath {
+				flagValue = flavor + "=" + resolveProjectConfigPath(configPath)
+			}
+			if err := cmd.Flags().Set(name, flagValue); err != nil {
+				return fmt.Errorf("cannot read %v: invalid value for '%v': %w", projectConfigPath, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedSettingNames(settings map[string]interface{}) []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func resolveProjectConfigPath(filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filePath
+	}
+	return filepath.Join(appDir, filePath)
+}
+
+func projectConfigValues(value interface{}) ([]string, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return []string{typedValue}, nil
+	case bool:
+		return []string{strconv.FormatBool(typedValue)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(typedValue, 'f', -1, 64)}, nil
+	case []interface{}:
+		var values []string
+		for _, element := range typedValue {
+			elementValues, err := projectConfigValues(element)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, elementValues...)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v", value)
+	}
+}
@@ -0,0 +1,127 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// newProjectConfigTestCommand returns a command with a few of the flags of the configurator, the project config file is written to a new app
+// dir and the arguments are parsed as if they were given on the command line.
+func newProjectConfigTestCommand(t *testing.T, name string, projectConfig string, args ...string) *cobra.Command {
+	previousAppDir := appDir
+	appDir = t.TempDir()
+	projectFlavorConfigPaths = make(map[string]string)
+	t.Cleanup(func() { appDir = previousAppDir })
+	if err := os.WriteFile(filepath.Join(appDir, projectConfigFileName), []byte(projectConfig), 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cmd := &cobra.Command{Use: name}
+	cmd.Flags().String("config", "", "")
+	cmd.Flags().String("module-name", "", "")
+	cmd.Flags().String("target-name", "", "")
+	cmd.Flags().StringP("flavor-name", "f", "", "")
+	cmd.Flags().StringArray("flavor", nil, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return cmd
+}
+
+func TestProjectConfigFlagsTakePrecedenceOverFile(t *testing.T) {
+	cmd := newProjectConfigTestCommand(t, "android", `{"config": "config.zip", "module-name": "app", "dry-run": true, "android": {"module-name": "library"}}`,
+		"--module-name", "cli", "--dry-run=false")
+
+	if err := loadProjectConfigFile(cmd, "android"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if moduleName, _ := cmd.Flags().GetString("module-name"); moduleName != "cli" {
+		t.Errorf("Incorrect result, expected the module name of the command line but got %v", moduleName)
+	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		t.Errorf("Incorrect result, expected the dry run flag of the command line")
+	}
+	if config, _ := cmd.Flags().GetString("config"); config != filepath.Join(appDir, "config.zip") {
+		t.Errorf("Incorrect result, expected the config zip relative to the app dir but got %v", config)
+	}
+}
+
+func TestProjectConfigSectionOverrides(t *testing.T) {
+	projectConfig := `{
+		"module-name": "app",
+		"flavor": ["dev=dev.zip"],
+		"flavor-name": "demo",
+		"android": {"module-name": "android", "flavor": ["dev=dev.zip", "prod=prod.zip"]},
+		"ios": {"module-name": "ios"},
+		"flavors": {"demo": {"config": "demo.zip"}, "prod": {"module-name": "prod"}}
+	}`
+	cmd := newProjectConfigTestCommand(t, "android", projectConfig)
+
+	if err := loadProjectConfigFile(cmd, "android"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if moduleName, _ := cmd.Flags().GetString("module-name"); moduleName != "android" {
+		t.Errorf("Incorrect result, expected the module name of the android section but got %v", moduleName)
+	}
+	// the android section replaces the flavors of the top level instead of adding to them
+	expectedFlavors := []string{"dev=" + filepath.Join(appDir, "dev.zip"), "prod=" + filepath.Join(appDir, "prod.zip")}
+	if flavors, _ := cmd.Flags().GetStringArray("flavor"); !reflect.DeepEqual(flavors, expectedFlavors) {
+		t.Errorf("Incorrect result, expected %v but got %v", expectedFlavors, flavors)
+	}
+	if config, _ := cmd.Flags().GetString("config"); config != filepath.Join(appDir, "demo.zip") {
+		t.Errorf("Incorrect result, expected the config zip of the demo flavor but got %v", config)
+	}
+
+	cmd = newProjectConfigTestCommand(t, "android", projectConfig, "-f", "prod")
+	if err := loadProjectConfigFile(cmd, "android"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if moduleName, _ := cmd.Flags().GetString("module-name"); moduleName != "prod" {
+		t.Errorf("Incorrect result, expected the module name of the prod flavor section but got %v", moduleName)
+	}
+}
+
+func TestProjectConfigConflictingPlatformSections(t *testing.T) {
+	cmd := newProjectConfigTestCommand(t, "all", `{"android": {"module-name": "app", "config": "android.zip"}, "ios": {"target-name": "App", "config": "ios.zip"}}`)
+	if err := loadProjectConfigFile(cmd, "android", "ios"); err == nil || !strings.Contains(err.Error(), "'config'") {
+		t.Errorf("Incorrect result, expected an error about the config in both platform sections but got %v", err)
+	}
+
+	cmd = newProjectConfigTestCommand(t, "all", `{"android": {"module-name": "app", "config": "app.zip"}, "ios": {"target-name": "App", "config": "app.zip"}}`)
+	if err := loadProjectConfigFile(cmd, "android", "ios"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if targetName, _ := cmd.Flags().GetString("target-name"); targetName != "App" {
+		t.Errorf("Incorrect result, expected the target name of the ios section but got %v", targetName)
+	}
+}
+
+func TestProjectConfigUnsupportedSettings(t *testing.T) {
+	// the settings are applied in the order of their names, so the error is always about the same setting
+	for i := 0; i < 10; i++ {
+		cmd := newProjectConfigTestCommand(t, "android", `{"unknown-b": "b", "unknown-a": "a", "module-name": "app"}`)
+		if err := loadProjectConfigFile(cmd, "android"); err == nil || !strings.Contains(err.Error(), "'unknown-a'") {
+			t.Fatalf("Incorrect result, expected an error about 'unknown-a' but got %v", err)
+		}
+	}
+}
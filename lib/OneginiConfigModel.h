@@ -2,7 +2,10 @@
 
 @interface OneginiConfigModel : NSObject
 
+// sdk-configurator: begin generated declarations
 + (NSArray *)certificates;
 + (NSDictionary *)configuration;
++ (NSArray *)resourceBaseURLs;
+// sdk-configurator: end generated declarations
 
-@end
\ No newline at end of file
+@end
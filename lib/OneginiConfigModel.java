@@ -1,22 +1,26 @@
-package com.onegini.example;
+package {{.PackageName}};
 
 import android.os.Build;
+import java.util.Arrays;
+import java.util.List;
 import com.onegini.mobile.sdk.android.model.OneginiClientConfigModel;
 
 public class OneginiConfigModel implements OneginiClientConfigModel {
 
-  /* Config model generated by SDK Configurator version: CONFIGURATOR_VERSION */
+  /* Config model generated by SDK Configurator version: {{.ConfiguratorVersion}} */
 
-  private final String appIdentifier = "value_will_be_replaced";
+  private final String appIdentifier = {{string .AppIdentifier}};
   private final String appPlatform = "android";
-  private final String redirectionUri = "value_will_be_replaced";
-  private final String appVersion = "value_will_be_replaced";
-  private final String baseURL = "value_will_be_replaced";
-  private final String resourceBaseURL = "value_will_be_replaced";
-  private final String keystoreHash = "value_will_be_replaced";
-  private final String serverPublicKey = null;
-  private final String serverType = "value_will_be_replaced";
-  private final String serverVersion = "value_will_be_replaced";
+  private final String redirectionUri = {{string .RedirectUrl}};
+  private final String appVersion = {{string .AppVersion}};
+  private final String baseURL = {{string .TokenServerUri}};
+  private final int maxPinFailures = {{.MaxPinFailures}};
+  private final String resourceBaseURL = {{string .ResourceBaseUrl}};
+  private final List<String> resourceBaseURLs = Arrays.asList({{strings .ResourceBaseUrls}});
+  private final String keystoreHash = {{string .KeystoreHash}};
+  private final String serverPublicKey = {{if .ServerPublicKey}}{{string .ServerPublicKey}}{{else}}null{{end}};
+  private final String serverType = {{string .ServerType}};
+  private final String serverVersion = {{string .ServerVersion}};
 
   public String getAppIdentifier() {
     return appIdentifier;
@@ -42,6 +46,10 @@ public class OneginiConfigModel implements OneginiClientConfigModel {
     return resourceBaseURL;
   }
 
+  public List<String> getResourceBaseUrls() {
+    return resourceBaseURLs;
+  }
+
   public int getCertificatePinningKeyStore() {
     return R.raw.keystore;
   }
@@ -76,6 +84,7 @@ public class OneginiConfigModel implements OneginiClientConfigModel {
             ", baseURL='" + baseURL + "'" +
             ", maxPinFailures='" + maxPinFailures + "'" +
             ", resourceBaseURL='" + resourceBaseURL + "'" +
+            ", resourceBaseURLs='" + resourceBaseURLs + "'" +
             ", keyStoreHash='" + getKeyStoreHash() + "'" +
             ", serverPublicKey='" + serverPublicKey + "'" +
             ", serverType='" + serverType + "'" +
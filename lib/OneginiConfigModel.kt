@@ -1,20 +1,21 @@
-package com.onegini.mobile.sdk.android
+package {{.PackageName}}
 
 import android.os.Build
 import com.onegini.mobile.sdk.android.model.OneginiClientConfigModel
 
 class OneginiConfigModel : OneginiClientConfigModel {
-  /* Config model generated by SDK Configurator version: CONFIGURATOR_VERSION */
-  override val appIdentifier = "value_will_be_replaced" 
+  /* Config model generated by SDK Configurator version: {{.ConfiguratorVersion}} */
+  override val appIdentifier = {{string .AppIdentifier}}
   override val appPlatform = "android"
-  override val redirectUri = "value_will_be_replaced"
-  override val appVersion = "value_will_be_replaced"
-  override val baseUrl = "value_will_be_replaced"
-  override val resourceBaseUrl = "value_will_be_replaced"
-  override val keyStoreHash = "value_will_be_replaced"
-  override val serverPublicKey: String? = null
-  override val serverType = "value_will_be_replaced"
-  override val serverVersion = "value_will_be_replaced"
+  override val redirectUri = {{string .RedirectUrl}}
+  override val appVersion = {{string .AppVersion}}
+  override val baseUrl = {{string .TokenServerUri}}
+  override val resourceBaseUrl = {{string .ResourceBaseUrl}}
+  val resourceBaseUrls: List<String> = listOf({{strings .ResourceBaseUrls}})
+  override val keyStoreHash = {{string .KeystoreHash}}
+  override val serverPublicKey: String? = {{if .ServerPublicKey}}{{string .ServerPublicKey}}{{else}}null{{end}}
+  override val serverType = {{string .ServerType}}
+  override val serverVersion = {{string .ServerVersion}}
   override val certificatePinningKeyStore = R.raw.keystore
   override val deviceName = "${Build.BRAND} ${Build.MODEL}"
  
@@ -26,6 +27,7 @@ class OneginiConfigModel : OneginiClientConfigModel {
         ", appVersion='" + appVersion + "'" +
         ", baseUrl='" + baseUrl + "'" +
         ", resourceBaseUrl='" + resourceBaseUrl + "'" +
+        ", resourceBaseUrls='" + resourceBaseUrls + "'" +
         ", keyStoreHash='" + keyStoreHash + "'" +
         ", serverPublicKey='" + serverPublicKey + "'" +
         ", serverType='" + serverType + "'" +
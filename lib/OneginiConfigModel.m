@@ -2,30 +2,46 @@
 
 @implementation OneginiConfigModel
 
-// Config model generated by SDK Configurator version: CONFIGURATOR_VERSION
+// sdk-configurator: begin generated header
+// Config model generated by SDK Configurator version: {{.ConfiguratorVersion}}
+// Code between the sdk-configurator markers is replaced when the configurator runs again, code outside of the markers is preserved.
+// sdk-configurator: end generated header
 
+// sdk-configurator: begin generated certificates
 + (NSArray *)certificates
 {
-    return @[@""]; //Base64Certificates
+    return @[{{strings .Certificates}}]; //Base64Certificates
 }
+// sdk-configurator: end generated certificates
 
 + (NSDictionary *)configuration
 {
     return @{
-             @"ONGServerType" : @"",
-             @"ONGServerVersion" : @"",
-             @"ONGAppIdentifier" : @"",
+             // sdk-configurator: begin generated configuration
+             @"ONGServerType" : {{string .ServerType}},
+             @"ONGServerVersion" : {{string .ServerVersion}},
+             @"ONGAppIdentifier" : {{string .AppIdentifier}},
              @"ONGAppPlatform" : @"ios",
-             @"ONGAppVersion" : @"",
-             @"ONGAppBaseURL" : @"",
-             @"ONGResourceBaseURL" : @"",
-             @"ONGRedirectURL" : @"",
+             @"ONGAppVersion" : {{string .AppVersion}},
+             @"ONGAppBaseURL" : {{string .TokenServerUri}},
+             @"ONGResourceBaseURL" : {{string .ResourceBaseUrl}},
+             @"ONGRedirectURL" : {{string .RedirectUrl}},
+             // sdk-configurator: end generated configuration
              };
 }
 
+// sdk-configurator: begin generated resourceBaseURLs
++ (NSArray *)resourceBaseURLs
+{
+    return @[{{strings .ResourceBaseUrls}}];
+}
+// sdk-configurator: end generated resourceBaseURLs
+
+// sdk-configurator: begin generated serverPublicKey
 + (NSString *)serverPublicKey
 {
-    return @"";
+    return {{string .ServerPublicKey}};
 }
+// sdk-configurator: end generated serverPublicKey
 
 @end
@@ -0,0 +1,43 @@
+import Foundation
+
+@objc(OneginiConfigModel)
+class OneginiConfigModel: NSObject {
+
+    // sdk-configurator: begin generated header
+    // Config model generated by SDK Configurator version: {{.ConfiguratorVersion}}
+    // Code between the sdk-configurator markers is replaced when the configurator runs again, code outside of the markers is preserved.
+    // sdk-configurator: end generated header
+
+    // sdk-configurator: begin generated certificates
+    @objc class func certificates() -> [String] {
+        return [{{strings .Certificates}}] //Base64Certificates
+    }
+    // sdk-configurator: end generated certificates
+
+    @objc class func configuration() -> [String: String] {
+        return [
+            // sdk-configurator: begin generated configuration
+            "ONGServerType": {{string .ServerType}},
+            "ONGServerVersion": {{string .ServerVersion}},
+            "ONGAppIdentifier": {{string .AppIdentifier}},
+            "ONGAppPlatform": "ios",
+            "ONGAppVersion": {{string .AppVersion}},
+            "ONGAppBaseURL": {{string .TokenServerUri}},
+            "ONGResourceBaseURL": {{string .ResourceBaseUrl}},
+            "ONGRedirectURL": {{string .RedirectUrl}},
+            // sdk-configurator: end generated configuration
+        ]
+    }
+
+    // sdk-configurator: begin generated resourceBaseURLs
+    @objc class func resourceBaseURLs() -> [String] {
+        return [{{strings .ResourceBaseUrls}}]
+    }
+    // sdk-configurator: end generated resourceBaseURLs
+
+    // sdk-configurator: begin generated serverPublicKey
+    @objc class func serverPublicKey() -> String {
+        return {{string .ServerPublicKey}}
+    }
+    // sdk-configurator: end generated serverPublicKey
+}
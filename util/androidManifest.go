@@ -0,0 +1,137 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	manifestActivityRegexp       = regexp.MustCompile(`(?s)<activity\s[^>]*?(?:/>|>.*?</activity>)`)
+	manifestActivityNameRegexp   = regexp.MustCompile(`^<activity\s[^>]*?android:name="([^"]*)"`)
+	oneginiIntentFilterRegexp    = regexp.MustCompile(`(?s)\s*<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent"[^>]*>(.*?)</intent-filter>`)
+	oneginiIntentFilterTagRegexp = regexp.MustCompile(`<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent"[^>]*>`)
+	manifestLauncherCategoryText = `android:name="android.intent.category.LAUNCHER"`
+)
+
+// UpdateManifestRedirectIntentFilter adds the OneginiRedirectionIntent intent-filter for the redirect URL to the activity, or updates it when the
+// activity already has one. An intent-filter on any other activity is removed, so only the selected activity handles the redirect URL. When no
+// activity name is given the launcher activity is used.
+func UpdateManifestRedirectIntentFilter(manifest string, activityName string, redirectUrl *url.URL) (string, error) {
+	activityLocation, err := findManifestActivity(manifest, activityName)
+	if err != nil {
+		return "", err
+	}
+	activity := manifest[activityLocation[0]:activityLocation[1]]
+	indentation := activityIndentation(manifest, activityLocation[0])
+	if oneginiIntentFilterRegexp.MatchString(activity) {
+		activity = replaceManifestIntentFilter(activity, indentation, redirectUrl)
+	} else {
+		activity = addManifestIntentFilter(activity, indentation, redirectUrl)
+	}
+
+	before := oneginiIntentFilterRegexp.ReplaceAllString(manifest[:activityLocation[0]], "")
+	after := oneginiIntentFilterRegexp.ReplaceAllString(manifest[activityLocation[1]:], "")
+	return before + activity + after, nil
+}
+
+// findManifestActivity returns the location of the activity element with the given name, or of the launcher activity when the name is empty.
+func findManifestActivity(manifest string, activityName string) ([]int, error) {
+	for _, location := range manifestActivityRegexp.FindAllStringIndex(manifest, -1) {
+		activity := manifest[location[0]:location[1]]
+		if len(activityName) == 0 {
+			if strings.Contains(activity, manifestLauncherCategoryText) {
+				return location, nil
+			}
+			continue
+		}
+
+		nameMatches := manifestActivityNameRegexp.FindStringSubmatch(activity)
+		if nameMatches != nil && isSameActivityName(nameMatches[1], activityName) {
+			return location, nil
+		}
+	}
+
+	if len(activityName) == 0 {
+		return nil, fmt.Errorf("%w: there is no launcher activity", ErrActivityNotFound)
+	}
+	return nil, fmt.Errorf("%w: there is no activity named '%v'", ErrActivityNotFound, activityName)
+}
+
+// isSameActivityName compares activity names that may be relative to the package (".MainActivity" or "MainActivity") or fully qualified.
+func isSameActivityName(manifestName string, activityName string) bool {
+	manifestName = strings.TrimPrefix(manifestName, ".")
+	activityName = strings.TrimPrefix(activityName, ".")
+	return manifestName == activityName || strings.HasSuffix(manifestName, "."+activityName) || strings.HasSuffix(activityName, "."+manifestName)
+}
+
+func activityIndentation(manifest string, activityStart int) string {
+	lineStart := strings.LastIndex(manifest[:activityStart], "\n") + 1
+	indentation := manifest[lineStart:activityStart]
+	if strings.TrimSpace(indentation) != "" {
+		return ""
+	}
+	return indentation
+}
+
+func addManifestIntentFilter(activity string, indentation string, redirectUrl *url.URL) string {
+	childIndentation := indentation + manifestIndentationUnit(indentation)
+	intentFilter := manifestIntentFilter(childIndentation, manifestIndentationUnit(indentation), redirectUrl)
+
+	if strings.HasSuffix(activity, "/>") {
+		return strings.TrimSpace(strings.TrimSuffix(activity, "/>")) + ">\n" + childIndentation + intentFilter + "\n" + indentation + "</activity>"
+	}
+	closingTagStart := strings.LastIndex(activity, "</activity>")
+	content := strings.TrimRight(activity[:closingTagStart], " \t\n")
+	return content + "\n" + childIndentation + intentFilter + "\n" + indentation + "</activity>"
+}
+
+// replaceManifestIntentFilter rebuilds the existing OneginiRedirectionIntent intent-filter of the activity in its place, so that an intent-filter
+// with a missing action or category is repaired as well. Any other OneginiRedirectionIntent intent-filter of the activity is removed.
+func replaceManifestIntentFilter(activity string, indentation string, redirectUrl *url.URL) string {
+	replaced := false
+	return oneginiIntentFilterRegexp.ReplaceAllStringFunc(activity, func(existingIntentFilter string) string {
+		if replaced {
+			return ""
+		}
+		replaced = true
+
+		leadingSpace := existingIntentFilter[:strings.Index(existingIntentFilter, "<")]
+		intentFilterIndentation := leadingSpace[strings.LastIndex(leadingSpace, "\n")+1:]
+		return leadingSpace + manifestIntentFilter(intentFilterIndentation, manifestIndentationUnit(indentation), redirectUrl)
+	})
+}
+
+// manifestIntentFilter returns the OneginiRedirectionIntent intent-filter for the redirect URL, the first line is not indented.
+func manifestIntentFilter(indentation string, indentationUnit string, redirectUrl *url.URL) string {
+	return strings.Join([]string{
+		string(prepareIntentFilterTag(redirectUrl.Scheme)),
+		indentationUnit + `<action android:name="android.intent.action.VIEW" />`,
+		indentationUnit + `<category android:name="android.intent.category.DEFAULT" />`,
+		indentationUnit + `<category android:name="android.intent.category.BROWSABLE" />`,
+		indentationUnit + string(prepareScheme(redirectUrl.Scheme, redirectUrl.Host, redirectUrl.Path)),
+		`</intent-filter>`,
+	}, "\n"+indentation)
+}
+
+func manifestIndentationUnit(indentation string) string {
+	if strings.Contains(indentation, "\t") {
+		return "\t"
+	}
+	return "    "
+}
@@ -0,0 +1,131 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const nativeManifest = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <application android:label="Example">
+        <activity android:name=".SettingsActivity" android:exported="false" />
+        <activity android:name=".MainActivity" android:exported="true">
+            <intent-filter>
+                <action android:name="android.intent.action.MAIN" />
+                <category android:name="android.intent.category.LAUNCHER" />
+            </intent-filter>
+        </activity>
+    </application>
+</manifest>`
+
+const nativeManifestWithIntentFilter = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android">
+    <application android:label="Example">
+        <activity android:name=".SettingsActivity" android:exported="false" />
+        <activity android:name=".MainActivity" android:exported="true">
+            <intent-filter>
+                <action android:name="android.intent.action.MAIN" />
+                <category android:name="android.intent.category.LAUNCHER" />
+            </intent-filter>
+            <intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent">
+                <action android:name="android.intent.action.VIEW" />
+                <category android:name="android.intent.category.DEFAULT" />
+                <category android:name="android.intent.category.BROWSABLE" />
+                <data android:scheme="example" android:host="loginsuccess" />
+            </intent-filter>
+        </activity>
+    </application>
+</manifest>`
+
+func TestUpdateManifestRedirectIntentFilterAddsToLauncherActivity(t *testing.T) {
+	redirectUrl, _ := url.Parse("example://loginsuccess")
+	result, err := UpdateManifestRedirectIntentFilter(nativeManifest, "", redirectUrl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != nativeManifestWithIntentFilter {
+		t.Errorf("Incorrect result, got:\n%v", result)
+	}
+
+	// running again does not change the manifest
+	if result, _ := UpdateManifestRedirectIntentFilter(result, "", redirectUrl); result != nativeManifestWithIntentFilter {
+		t.Errorf("Incorrect result, expected the manifest to be unchanged but got:\n%v", result)
+	}
+}
+
+func TestUpdateManifestRedirectIntentFilterUpdatesExistingIntentFilter(t *testing.T) {
+	redirectUrl, _ := url.Parse("https://www.example.com/login-success")
+	result, err := UpdateManifestRedirectIntentFilter(nativeManifestWithIntentFilter, "MainActivity", redirectUrl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := strings.NewReplacer(
+		`android:name="OneginiRedirectionIntent">`, `android:name="OneginiRedirectionIntent" android:autoVerify="true">`,
+		`<data android:scheme="example" android:host="loginsuccess" />`, `<data android:scheme="https" android:host="www.example.com" android:pathPrefix="/login-success" />`,
+	).Replace(nativeManifestWithIntentFilter)
+	if result != expected {
+		t.Errorf("Incorrect result, got:\n%v", result)
+	}
+}
+
+func TestUpdateManifestRedirectIntentFilterRepairsExistingIntentFilter(t *testing.T) {
+	redirectUrl, _ := url.Parse("example://loginsuccess")
+	incompleteManifest := strings.Replace(nativeManifestWithIntentFilter,
+		"                <category android:name=\"android.intent.category.BROWSABLE\" />\n", "", 1)
+	result, err := UpdateManifestRedirectIntentFilter(incompleteManifest, "MainActivity", redirectUrl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != nativeManifestWithIntentFilter {
+		t.Errorf("Incorrect result, got:\n%v", result)
+	}
+}
+
+func TestUpdateManifestRedirectIntentFilterAddsAppLink(t *testing.T) {
+	redirectUrl, _ := url.Parse("https://www.example.com/login-success")
+	result, err := UpdateManifestRedirectIntentFilter(nativeManifest, "", redirectUrl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(result, `<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent" android:autoVerify="true">`) {
+		t.Errorf("Incorrect result, expected an intent-filter with autoVerify but got:\n%v", result)
+	}
+}
+
+func TestUpdateManifestRedirectIntentFilterMovesIntentFilterToSelectedActivity(t *testing.T) {
+	redirectUrl, _ := url.Parse("example://loginsuccess")
+	result, err := UpdateManifestRedirectIntentFilter(nativeManifestWithIntentFilter, "com.example.app.SettingsActivity", redirectUrl)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Count(result, "OneginiRedirectionIntent\">") != 1 {
+		t.Errorf("Incorrect result, expected a single intent-filter but got:\n%v", result)
+	}
+	settingsActivity := result[strings.Index(result, `<activity android:name=".SettingsActivity"`):strings.Index(result, `<activity android:name=".MainActivity"`)]
+	if !strings.Contains(settingsActivity, "OneginiRedirectionIntent") || !strings.Contains(settingsActivity, "</activity>") {
+		t.Errorf("Incorrect result, expected the intent-filter in the settings activity but got:\n%v", result)
+	}
+}
+
+func TestUpdateManifestRedirectIntentFilterUnknownActivity(t *testing.T) {
+	redirectUrl, _ := url.Parse("example://loginsuccess")
+	if _, err := UpdateManifestRedirectIntentFilter(nativeManifest, "UnknownActivity", redirectUrl); !errors.Is(err, ErrActivityNotFound) {
+		t.Errorf("Incorrect result, expected an activity not found error but was: %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	gradleBlockHeaderRegexp       = regexp.MustCompile(`(?:^|[\s;(])(?:(\w+)|(?:create|register|getByName|maybeCreate|named)\(\s*["']([\w-]+)["']\s*\)|["']([\w-]+)["'])\s*$`)
+	gradleFlavorDimensionsRegexp  = regexp.MustCompile(`(?m)^\s*flavorDimensions\b(.*)$`)
+	gradleConfigurationBlockNames = map[string]bool{"all": true, "configureEach": true, "each": true, "forEach": true}
+)
+
+// androidDefaultBuildTypes are the build types that every Android module has, also when the build file does not declare them
+var androidDefaultBuildTypes = []string{"debug", "release"}
+
+// productFlavor is a product flavor that is declared in the productFlavors block of the build file.
+type productFlavor struct {
+	name      string
+	dimension string
+}
+
+// buildTypes returns the build types of the module, the debug and release build types followed by the build types that the build file declares.
+func (buildFile *gradleBuildFile) buildTypes() []string {
+	androidBlock, _ := gradleBlock(buildFile.content, "android")
+	buildTypes := append([]string{}, androidDefaultBuildTypes...)
+	if buildTypesBlock, ok := gradleBlock(androidBlock, "buildTypes"); ok {
+		for _, name := range gradleBlockNames(buildTypesBlock) {
+			if !containsString(buildTypes, name) {
+				buildTypes = append(buildTypes, name)
+			}
+		}
+	}
+	return buildTypes
+}
+
+// flavorDimensions returns the flavor dimensions in the order of their priority, e.g. `flavorDimensions "tier", "environment"` or
+// `flavorDimensions += listOf("tier", "environment")`.
+func (buildFile *gradleBuildFile) flavorDimensions() []string {
+	androidBlock, _ := gradleBlock(buildFile.content, "android")
+	var dimensions []string
+	for _, matches := range gradleFlavorDimensionsRegexp.FindAllStringSubmatch(removeNestedGradleBlocks(androidBlock), -1) {
+		for _, quotedDimension := range gradleQuotedPathRegexp.FindAllStringSubmatch(matches[1], -1) {
+			dimensions = append(dimensions, quotedDimension[1])
+		}
+	}
+	return dimensions
+}
+
+// productFlavors returns the product flavors that the build file declares. A flavor without a dimension belongs to the only flavor dimension
+// of the module.
+func (buildFile *gradleBuildFile) productFlavors() []productFlavor {
+	androidBlock, _ := gradleBlock(buildFile.content, "android")
+	productFlavorsBlock, ok := gradleBlock(androidBlock, "productFlavors")
+	if !ok {
+		return nil
+	}
+
+	var flavors []productFlavor
+	for _, name := range gradleBlockNames(productFlavorsBlock) {
+		flavorBlock, _ := gradleBlock(productFlavorsBlock, name)
+		flavors = append(flavors, productFlavor{name: name, dimension: buildFile.resolveSetting(flavorBlock, "dimension")})
+	}
+	return flavors
+}
+
+// splitFlavorName returns the flavors, one of every flavor dimension in the order of the dimensions, that are combined in the name of a
+// variant, e.g. the flavors free and staging for freeStaging. It returns false when the name is not a combination of the declared flavors.
+func (buildFile *gradleBuildFile) splitFlavorName(flavorName string) ([]string, bool) {
+	flavors := buildFile.productFlavors()
+	dimensions := buildFile.flavorDimensions()
+	if len(dimensions) <= 1 {
+		// the flavors of a single dimension don't need to name it
+		for _, flavor := range flavors {
+			if flavor.name == flavorName {
+				return []string{flavorName}, true
+			}
+		}
+		return nil, false
+	}
+
+	var splitFlavors func(name string, dimensionIndex int) ([]string, bool)
+	splitFlavors = func(name string, dimensionIndex int) ([]string, bool) {
+		if dimensionIndex == len(dimensions) {
+			return nil, len(name) == 0
+		}
+		for _, flavor := range flavors {
+			if flavor.dimension != dimensions[dimensionIndex] {
+				continue
+			}
+			prefix := flavor.name
+			if dimensionIndex > 0 {
+				prefix = capitalize(prefix)
+			}
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			if otherFlavors, ok := splitFlavors(strings.TrimPrefix(name, prefix), dimensionIndex+1); ok {
+				return append([]string{flavor.name}, otherFlavors...), true
+			}
+		}
+		return nil, false
+	}
+	return splitFlavors(flavorName, 0)
+}
+
+// ValidateAndroidVariant verifies that the flavor and the build type that are configured are declared in the build file of the module, so that
+// the keystore and the config model end up in a source set that Gradle uses. A build type needs a flavor of every flavor dimension, since Gradle
+// only has source sets for a build type on its own or for a complete variant.
+func ValidateAndroidVariant(config *Config) error {
+	if config.ConfigureForCordova || config.ConfigureForNativeScript {
+		if len(config.BuildType) > 0 {
+			config.AddWarning("Ignoring the build type parameter for Cordova or NativeScript")
+			config.BuildType = ""
+		}
+		return nil
+	}
+	if len(config.FlavorName) == 0 && len(config.BuildType) == 0 {
+		return nil
+	}
+
+	buildFile, err := config.loadAndroidBuildFile()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			config.AddWarning(fmt.Sprintf("Could not read the Gradle file to verify the build variant: %v", err))
+		}
+		return nil
+	}
+
+	if buildTypes := buildFile.buildTypes(); len(config.BuildType) > 0 && !containsString(buildTypes, config.BuildType) {
+		return fmt.Errorf("%w: the build type '%v' is not declared in '%v', use one of: %v", ErrInvalidBuildVariant, config.BuildType, buildFile.path,
+			strings.Join(buildTypes, ", "))
+	}
+	if len(config.FlavorName) == 0 {
+		return nil
+	}
+
+	flavors := buildFile.productFlavors()
+	if len(flavors) == 0 {
+		return fmt.Errorf("%w: the flavor '%v' is given but '%v' does not declare any product flavors", ErrInvalidBuildVariant, config.FlavorName,
+			buildFile.path)
+	}
+	if _, ok := buildFile.splitFlavorName(config.FlavorName); ok {
+		return nil
+	}
+	for _, flavor := range flavors {
+		if flavor.name == config.FlavorName {
+			if len(config.BuildType) > 0 {
+				return fmt.Errorf("%w: the build type '%v' needs a flavor of every flavor dimension (%v), e.g. '%v'", ErrInvalidBuildVariant,
+					config.BuildType, strings.Join(buildFile.flavorDimensions(), ", "), exampleFlavorName(buildFile, flavors))
+			}
+			// the source set of a single flavor is used by all variants with that flavor
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the flavor '%v' is not declared in '%v', use one of the flavors or a combination of one flavor of every flavor dimension, "+
+		"e.g. '%v'", ErrInvalidBuildVariant, config.FlavorName, buildFile.path, exampleFlavorName(buildFile, flavors))
+}
+
+// exampleFlavorName returns the name of the first combination of flavors of the module.
+func exampleFlavorName(buildFile *gradleBuildFile, flavors []productFlavor) string {
+	dimensions := buildFile.flavorDimensions()
+	if len(dimensions) <= 1 {
+		return flavors[0].name
+	}
+
+	flavorName := ""
+	for _, dimension := range dimensions {
+		for _, flavor := range flavors {
+			if flavor.dimension != dimension {
+				continue
+			}
+			if len(flavorName) == 0 {
+				flavorName = flavor.name
+			} else {
+				flavorName += capitalize(flavor.name)
+			}
+			break
+		}
+	}
+	return flavorName
+}
+
+// getAndroidSourceSetName returns the name of the source set of the flavor and the build type, e.g. freeStagingDebug. It is empty when neither
+// is configured.
+func (config *Config) getAndroidSourceSetName() string {
+	if len(config.FlavorName) == 0 {
+		return config.BuildType
+	}
+	return config.FlavorName + capitalize(config.BuildType)
+}
+
+// getAndroidVariantFlavors returns the flavors of the variant in the order of their flavor dimensions.
+func (config *Config) getAndroidVariantFlavors(buildFile *gradleBuildFile) []string {
+	if len(config.FlavorName) == 0 {
+		return nil
+	}
+	if flavors, ok := buildFile.splitFlavorName(config.FlavorName); ok {
+		return flavors
+	}
+	return []string{config.FlavorName}
+}
+
+// gradleBlockNames returns the names of the blocks in the content, e.g. the flavors in the productFlavors block. Nested blocks are skipped.
+func gradleBlockNames(content string) []string {
+	var names []string
+	depth := 0
+	headerStart := 0
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"', '\'':
+			i = skipGradleString(content, i)
+		case '{':
+			if depth == 0 {
+				matches := gradleBlockHeaderRegexp.FindStringSubmatch(content[headerStart:i])
+				if matches != nil && !gradleConfigurationBlockNames[matches[1]] {
+					names = append(names, matches[1]+matches[2]+matches[3])
+				}
+			}
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+			headerStart = i + 1
+		case '\n', ';':
+			if depth == 0 {
+				headerStart = i + 1
+			}
+		}
+	}
+	return names
+}
+
+func capitalize(value string) string {
+	if len(value) == 0 {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,140 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+const multiDimensionBuildFile = `android {
+    namespace = "com.example"
+    flavorDimensions += listOf("tier", "environment")
+
+    defaultConfig {
+        applicationId = "com.example"
+    }
+
+    buildTypes {
+        getByName("debug") {
+            applicationIdSuffix = ".debug"
+        }
+        create("qa") {
+            initWith(getByName("debug"))
+        }
+    }
+
+    productFlavors {
+        create("free") {
+            dimension = "tier"
+            applicationIdSuffix = ".free"
+        }
+        create("paid") {
+            dimension = "tier"
+            applicationId = "com.example.paid"
+        }
+        create("staging") {
+            dimension = "environment"
+            applicationIdSuffix = ".staging"
+        }
+        create("production") {
+            dimension = "environment"
+        }
+    }
+}
+`
+
+func TestAndroidVariantSourceSet(t *testing.T) {
+	testCases := []struct {
+		flavorName    string
+		buildType     string
+		sourceSet     string
+		applicationId string
+	}{
+		{"", "", "main", "com.example"},
+		{"", "qa", "qa", "com.example"},
+		{"free", "", "free", "com.example.free"},
+		{"freeStaging", "", "freeStaging", "com.example.staging.free"},
+		{"freeStaging", "debug", "freeStagingDebug", "com.example.staging.free.debug"},
+		{"paidProduction", "release", "paidProductionRelease", "com.example.paid"},
+	}
+	for _, testCase := range testCases {
+		config := writeGradleProject(t, map[string]string{"app/build.gradle.kts": multiDimensionBuildFile})
+		SetFlavorName(testCase.flavorName, config)
+		SetBuildType(testCase.buildType, config)
+
+		if err := ValidateAndroidVariant(config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := filepath.ToSlash(filepath.Join(config.AppDir, "app/src", testCase.sourceSet, "res/raw/keystore.bks"))
+		if keystorePath := config.getAndroidKeystorePath(); keystorePath != expected {
+			t.Errorf("Incorrect keystore path, expected %v but was %v", expected, keystorePath)
+		}
+		expected = filepath.ToSlash(filepath.Join(config.AppDir, "app/src", testCase.sourceSet, "java/com/example/OneginiConfigModel.java"))
+		if modelPath := config.getAndroidConfigModelJavaPath(); modelPath != expected {
+			t.Errorf("Incorrect config model path, expected %v but was %v", expected, modelPath)
+		}
+		if applicationId := config.getAndroidApplicationId(); applicationId != testCase.applicationId {
+			t.Errorf("Incorrect application ID for variant '%v', expected %v but was %v", config.getAndroidSourceSetName(), testCase.applicationId, applicationId)
+		}
+	}
+}
+
+func TestValidateAndroidVariantRejectsUndeclaredVariant(t *testing.T) {
+	testCases := []struct {
+		description string
+		flavorName  string
+		buildType   string
+	}{
+		{"unknown build type", "", "staging"},
+		{"unknown flavor", "trial", ""},
+		{"flavors in the wrong order", "stagingFree", ""},
+		{"build type with a partial flavor", "free", "debug"},
+	}
+	for _, testCase := range testCases {
+		config := writeGradleProject(t, map[string]string{"app/build.gradle.kts": multiDimensionBuildFile})
+		SetFlavorName(testCase.flavorName, config)
+		SetBuildType(testCase.buildType, config)
+
+		if err := ValidateAndroidVariant(config); !errors.Is(err, ErrInvalidBuildVariant) {
+			t.Errorf("Incorrect result for the %v, expected an invalid build variant error but got %v", testCase.description, err)
+		}
+	}
+}
+
+func TestValidateAndroidVariantWithoutFlavors(t *testing.T) {
+	config := writeGradleProject(t, map[string]string{"app/build.gradle": "android {\n    namespace 'com.example'\n}\n"})
+	SetFlavorName("development", config)
+
+	if err := ValidateAndroidVariant(config); !errors.Is(err, ErrInvalidBuildVariant) {
+		t.Errorf("Incorrect result, expected an invalid build variant error but got %v", err)
+	}
+}
+
+func TestGradleBlockNames(t *testing.T) {
+	content := `
+        development {
+            dimension "environment"
+        }
+        "staging" { }
+        register("production") { dimension = "environment" }
+        all { minifyEnabled false }
+`
+	names := gradleBlockNames(content)
+	if len(names) != 3 || names[0] != "development" || names[1] != "staging" || names[2] != "production" {
+		t.Errorf("Incorrect result, expected development, staging and production but got %v", names)
+	}
+}
@@ -16,78 +16,105 @@ package util
 
 import (
 	"fmt"
-	"io/ioutil"
-	"os"
-	"regexp"
 	"net/url"
+	"regexp"
 )
 
-func WriteAndroidAppScheme(config *Config) {
+func WriteAndroidAppScheme(config *Config) error {
 	if config.ConfigureForNativeScript {
-		return
+		return nil
 	}
 
 	manifestPath := config.getAndroidManifestPath()
-	manifestBytes := loadAndroidManifest(manifestPath)
-	parsedRedirectUrl := parseRedirectUrl(config.Options.RedirectUrl)
+	manifestBytes, err := loadAndroidManifest(config, manifestPath)
+	if err != nil {
+		return err
+	}
+	parsedRedirectUrl, err := parseRedirectUrl(config.Options.RedirectUrl)
+	if err != nil {
+		return err
+	}
 
-	if config.ConfigureForCordova {
+	if config.ConfigureForCordova || config.ConfigureForCapacitor {
 		manifestString := string(manifestBytes)
 		shouldRemoveIntentFilter := shouldRemoveIntentFilter(config)
 
 		manifestBytes = []byte(ReplaceManifest(manifestString, shouldRemoveIntentFilter, parsedRedirectUrl))
-		ioutil.WriteFile(manifestPath, manifestBytes, os.ModePerm)
+		config.writeFile(manifestPath, manifestBytes)
+	} else if config.updatesNativeAndroidManifest() {
+		manifestString, err := UpdateManifestRedirectIntentFilter(string(manifestBytes), config.ManifestActivity, parsedRedirectUrl)
+		if err != nil {
+			return fmt.Errorf("cannot update '%v': %w", manifestPath, err)
+		}
+		config.writeFile(manifestPath, []byte(manifestString))
 	}
+	return nil
+}
+
+// updatesNativeAndroidManifest returns whether the redirect intent-filter is added to the manifest with --update-manifest. The manifests of
+// Cordova, Capacitor and NativeScript projects are handled by their plugins.
+func (config *Config) updatesNativeAndroidManifest() bool {
+	return config.UpdateManifest && !config.ConfigureForCordova && !config.ConfigureForCapacitor && !config.ConfigureForNativeScript
 }
 
-func loadAndroidManifest(manifestPath string) []byte {
-	manifestBytes, err := ioutil.ReadFile(manifestPath)
+func loadAndroidManifest(config *Config, manifestPath string) ([]byte, error) {
+	manifestBytes, err := config.readFile(manifestPath)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the Android Manifest: %v.\n", err))
-		os.Exit(1)
+		return nil, fmt.Errorf("cannot read the Android Manifest: %w", err)
 	}
-	return manifestBytes
+	return manifestBytes, nil
 }
 
-func parseRedirectUrl(redirectUrl string) *url.URL {
+func parseRedirectUrl(redirectUrl string) (*url.URL, error) {
 	url, err := url.Parse(redirectUrl)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot parse provided redirectUrl: %v.\n", err))
-		os.Exit(1)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRedirectUrl, err)
 	}
-	return url
+	return url, nil
 }
 
+// cordovaMainActivityName is the activity of Cordova and Capacitor apps that the OneginiRedirectionIntent intent-filter is added to.
+const cordovaMainActivityName = "MainActivity"
+
+// ReplaceManifest updates the OneginiRedirectionIntent intent-filter of a Cordova or Capacitor app in the same way as
+// UpdateManifestRedirectIntentFilter, so a missing intent-filter is added to the MainActivity, or removes it when the Onegini web view is
+// disabled. The intent-filter of older Cordova plugins only gets the redirect URL in its <data> element. A manifest without the MainActivity
+// is left alone.
 func ReplaceManifest(manifest string, shouldRemoveIntentFilter bool, redirectUrl *url.URL) string {
-	scheme := redirectUrl.Scheme
-	host := redirectUrl.Host
-	path := redirectUrl.Path
-	manifestBytes := []byte(manifest)
-	newRegexp := regexp.MustCompile(`(?s)\s*<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent">(.*?)</intent-filter>`)
-	oldRegexp := regexp.MustCompile(`(?s)\s*<activity\s+.*android:name="MainActivity".*>.*<intent-filter>.*android:scheme="([^"]*)".*</intent-filter>.*</activity>`)
+	hasIntentFilter := oneginiIntentFilterRegexp.MatchString(manifest)
+	if hasIntentFilter && shouldRemoveIntentFilter {
+		return oneginiIntentFilterRegexp.ReplaceAllString(manifest, "")
+	}
 
-	schemeRegexp := regexp.MustCompile(`<data .*/>`)
-	if newRegexp.Match(manifestBytes) {
-		if shouldRemoveIntentFilter {
-			manifestBytes = newRegexp.ReplaceAll(manifestBytes, []byte(""))
-		} else {
-			manifestBytes = newRegexp.ReplaceAllFunc(manifestBytes, func(input []byte) (output []byte) {
-				output = schemeRegexp.ReplaceAllFunc(input, func(input []byte) (output []byte) {
-					output = prepareScheme(scheme, host, path)
-					return
-				})
-				return
-			})
-		}
-	} else {
+	oldRegexp := regexp.MustCompile(`(?s)\s*<activity\s+.*android:name="MainActivity".*>.*<intent-filter>.*android:scheme="([^"]*)".*</intent-filter>.*</activity>`)
+	if !hasIntentFilter && oldRegexp.MatchString(manifest) {
 		// backward compatible check for older versions of the cordova plugin
-		manifestBytes = oldRegexp.ReplaceAllFunc(manifestBytes, func(input []byte) (output []byte) {
-			output = schemeRegexp.ReplaceAll(input, prepareScheme(scheme, host, path))
-			return
+		schemeRegexp := regexp.MustCompile(`<data .*/>`)
+		return oldRegexp.ReplaceAllStringFunc(manifest, func(input string) string {
+			return schemeRegexp.ReplaceAllString(input, string(prepareScheme(redirectUrl.Scheme, redirectUrl.Host, redirectUrl.Path)))
 		})
 	}
 
-	return string(manifestBytes)
+	if shouldRemoveIntentFilter {
+		return manifest
+	}
+	updatedManifest, err := UpdateManifestRedirectIntentFilter(manifest, intentFilterActivityName(manifest), redirectUrl)
+	if err != nil {
+		return manifest
+	}
+	return updatedManifest
+}
+
+// intentFilterActivityName returns the name of the activity with the OneginiRedirectionIntent intent-filter, or the MainActivity when the
+// manifest does not contain one.
+func intentFilterActivityName(manifest string) string {
+	for _, activity := range manifestActivityRegexp.FindAllString(manifest, -1) {
+		nameMatches := manifestActivityNameRegexp.FindStringSubmatch(activity)
+		if nameMatches != nil && oneginiIntentFilterRegexp.MatchString(activity) {
+			return nameMatches[1]
+		}
+	}
+	return cordovaMainActivityName
 }
 
 func shouldRemoveIntentFilter(config *Config) bool {
@@ -96,15 +123,25 @@ func shouldRemoveIntentFilter(config *Config) bool {
 			return true
 		}
 	}
-	return false
+	// Capacitor passes the preferences of Cordova plugins in the cordova section of its config
+	return config.Capacitor.Cordova.Preferences["OneginiWebView"] == "disabled"
+}
+
+// prepareIntentFilterTag returns the opening tag of the Onegini intent-filter. Android only verifies the App Link of an https redirect URL
+// when the intent-filter sets autoVerify.
+func prepareIntentFilterTag(scheme string) []byte {
+	if scheme == "https" {
+		return []byte(`<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent" android:autoVerify="true">`)
+	}
+	return []byte(`<intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent">`)
 }
 
 func prepareScheme(scheme string, host string, path string) []byte {
-	stringToInject := "<data android:scheme=\""+scheme+"\" android:host=\""+host+"\""
-	if (path != "") {
-		stringToInject = stringToInject+" android:pathPrefix=\""+path+"\""
+	stringToInject := "<data android:scheme=\"" + scheme + "\" android:host=\"" + host + "\""
+	if path != "" {
+		stringToInject = stringToInject + " android:pathPrefix=\"" + path + "\""
 	}
-	stringToInject = stringToInject+" />"
+	stringToInject = stringToInject + " />"
 
 	return []byte(stringToInject)
 }
@@ -0,0 +1,85 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const assetLinksRelation = "delegate_permission/common.handle_all_urls"
+
+// AssetLinks is the Digital Asset Links file that the host of an https redirect URL must publish, so that Android verifies the App Link of
+// the app.
+type AssetLinks struct {
+	Url     string      `json:"url"`
+	Content []AssetLink `json:"content"`
+}
+
+type AssetLink struct {
+	Relation []string        `json:"relation"`
+	Target   AssetLinkTarget `json:"target"`
+}
+
+type AssetLinkTarget struct {
+	Namespace              string   `json:"namespace"`
+	PackageName            string   `json:"package_name"`
+	SHA256CertFingerprints []string `json:"sha256_cert_fingerprints"`
+}
+
+// CreateAssetLinks creates the Digital Asset Links file for the package of the app and the fingerprints of the certificates it is signed with. It requires an
+// https redirect URL, a custom scheme is not verified by Android.
+func CreateAssetLinks(config *Config) error {
+	if len(config.AssetLinksCert) == 0 {
+		return nil
+	}
+
+	redirectUrl, err := parseRedirectUrl(config.Options.RedirectUrl)
+	if err != nil {
+		return err
+	}
+	if redirectUrl.Scheme != "https" {
+		config.AddWarning("Ignoring the assetlinks certificate parameter, Android only verifies App Links for an https redirect URL")
+		return nil
+	}
+
+	packageName := config.getAndroidApplicationId()
+	if len(packageName) == 0 {
+		return errors.New("cannot create the Digital Asset Links file: the package name of the app is unknown")
+	}
+	fingerprints, err := readSigningCertificateFingerprints(config.AssetLinksCert, config.AssetLinksKeystorePassword, config.AssetLinksKeyAlias)
+	if err != nil {
+		return err
+	}
+
+	target := AssetLinkTarget{Namespace: "android_app", PackageName: packageName, SHA256CertFingerprints: fingerprints}
+	config.assetLinks = &AssetLinks{
+		Url:     fmt.Sprintf("https://%v/.well-known/assetlinks.json", redirectUrl.Host),
+		Content: []AssetLink{{Relation: []string{assetLinksRelation}, Target: target}},
+	}
+	return nil
+}
+
+func PrintAssetLinks(config *Config) {
+	if config.assetLinks == nil {
+		return
+	}
+
+	content, _ := json.MarshalIndent(config.assetLinks.Content, "", "  ")
+	fmt.Println("")
+	fmt.Printf("INFO: Publish the following Digital Asset Links file at %v to let Android verify the App Link:\n", config.assetLinks.Url)
+	fmt.Println(string(content))
+}
@@ -0,0 +1,54 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateAssetLinks(t *testing.T) {
+	config := &Config{Options: &options{RedirectUrl: "https://login.example.com/login-success"}}
+	config.AndroidManifest.PackageID = "com.example.app"
+	SetAssetLinksCertificate(filepath.Join("testdata", "release.p12"), "secret", "", config)
+
+	if err := CreateAssetLinks(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.assetLinks == nil || config.assetLinks.Url != "https://login.example.com/.well-known/assetlinks.json" {
+		t.Fatalf("Incorrect result, expected the asset links of login.example.com but got %+v", config.assetLinks)
+	}
+
+	content, _ := json.Marshal(config.assetLinks.Content)
+	expected := `[{"relation":["delegate_permission/common.handle_all_urls"],"target":{"namespace":"android_app","package_name":"com.example.app",` +
+		`"sha256_cert_fingerprints":["` + releaseCertFingerprint + `"]}}]`
+	if string(content) != expected {
+		t.Errorf("Incorrect result, expected %v but got %v", expected, string(content))
+	}
+}
+
+func TestCreateAssetLinksIgnoresCustomScheme(t *testing.T) {
+	config := &Config{Options: &options{RedirectUrl: "example://loginsuccess"}}
+	config.AndroidManifest.PackageID = "com.example.app"
+	SetAssetLinksCertificate(filepath.Join("testdata", "release.p12"), "secret", "", config)
+
+	if err := CreateAssetLinks(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.assetLinks != nil || len(config.Warnings) != 1 {
+		t.Errorf("Incorrect result, expected a warning instead of asset links for a custom scheme")
+	}
+}
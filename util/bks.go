@@ -0,0 +1,206 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"sort"
+	"time"
+	"unicode/utf16"
+)
+
+// The BouncyCastle BKS keystore format as written by bcprov-jdk15on-1.46, which is the version the configurator used to ship with. Version 1
+// derives a (short) 16 bit HMAC key, later BouncyCastle releases (version 2) derive a full 160 bit key. Android can read both.
+const (
+	bksVersion          = 1
+	bksSaltSize         = 20
+	bksMinIterations    = 1024
+	bksEntryCertificate = 1
+	bksEntryEnd         = 0
+	bksCertificateType  = "X.509"
+	pkcs12MacMaterial   = 3
+)
+
+type bksCertificate struct {
+	alias string
+	der   []byte
+}
+
+// encodeBksKeystore returns a BKS keystore containing the given DER encoded certificates as trusted certificate entries, protected with
+// an HMAC derived from the password.
+func encodeBksKeystore(certs []bksCertificate, password string, created time.Time) ([]byte, error) {
+	salt := make([]byte, bksSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	iterations, err := rand.Int(rand.Reader, big.NewInt(0x400))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeBksKeystoreWithSalt(certs, password, created, salt, bksMinIterations+int(iterations.Int64()))
+}
+
+func encodeBksKeystoreWithSalt(certs []bksCertificate, password string, created time.Time, salt []byte, iterations int) ([]byte, error) {
+	header := new(bytes.Buffer)
+	writeInt32(header, bksVersion)
+	writeInt32(header, int32(len(salt)))
+	header.Write(salt)
+	writeInt32(header, int32(iterations))
+
+	sorted := make([]bksCertificate, len(certs))
+	copy(sorted, certs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].alias < sorted[j].alias })
+
+	body := new(bytes.Buffer)
+	for _, cert := range sorted {
+		if err := writeBksCertificateEntry(body, cert, created); err != nil {
+			return nil, err
+		}
+	}
+	body.WriteByte(bksEntryEnd)
+
+	macKey := pkcs12DeriveKey(sha1.New, bmpPassword(password), salt, iterations, pkcs12MacMaterial, sha1.Size*bksVersion/8)
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(body.Bytes())
+
+	keystore := new(bytes.Buffer)
+	keystore.Write(header.Bytes())
+	keystore.Write(body.Bytes())
+	keystore.Write(mac.Sum(nil))
+
+	return keystore.Bytes(), nil
+}
+
+func writeBksCertificateEntry(buffer *bytes.Buffer, cert bksCertificate, created time.Time) error {
+	buffer.WriteByte(bksEntryCertificate)
+	if err := writeJavaUTF(buffer, cert.alias); err != nil {
+		return err
+	}
+	writeInt64(buffer, created.UnixNano()/int64(time.Millisecond))
+	// trusted certificate entries don't have a certificate chain
+	writeInt32(buffer, 0)
+
+	if err := writeJavaUTF(buffer, bksCertificateType); err != nil {
+		return err
+	}
+	writeInt32(buffer, int32(len(cert.der)))
+	buffer.Write(cert.der)
+
+	return nil
+}
+
+func writeInt32(buffer *bytes.Buffer, value int32) {
+	_ = binary.Write(buffer, binary.BigEndian, value)
+}
+
+func writeInt64(buffer *bytes.Buffer, value int64) {
+	_ = binary.Write(buffer, binary.BigEndian, value)
+}
+
+// writeJavaUTF mimics java.io.DataOutputStream#writeUTF, which writes a length prefixed modified UTF-8 string.
+func writeJavaUTF(buffer *bytes.Buffer, value string) error {
+	encoded := make([]byte, 0, len(value))
+	for _, char := range utf16.Encode([]rune(value)) {
+		switch {
+		case char >= 0x0001 && char <= 0x007f:
+			encoded = append(encoded, byte(char))
+		case char <= 0x07ff:
+			encoded = append(encoded, byte(0xc0|(char>>6)&0x1f), byte(0x80|char&0x3f))
+		default:
+			encoded = append(encoded, byte(0xe0|(char>>12)&0x0f), byte(0x80|(char>>6)&0x3f), byte(0x80|char&0x3f))
+		}
+	}
+
+	if len(encoded) > 0xffff {
+		return errors.New("string too long to be encoded in a keystore")
+	}
+	_ = binary.Write(buffer, binary.BigEndian, uint16(len(encoded)))
+	buffer.Write(encoded)
+
+	return nil
+}
+
+// bmpPassword converts the password into a null terminated big endian UTF-16 string as expected by the PKCS#12 key derivation.
+func bmpPassword(password string) []byte {
+	if len(password) == 0 {
+		return []byte{}
+	}
+
+	chars := utf16.Encode([]rune(password))
+	bmp := make([]byte, 0, len(chars)*2+2)
+	for _, char := range chars {
+		bmp = append(bmp, byte(char>>8), byte(char))
+	}
+
+	return append(bmp, 0, 0)
+}
+
+// pkcs12DeriveKey implements the PKCS#12 key derivation function (RFC 7292, appendix B.2).
+func pkcs12DeriveKey(newHash func() hash.Hash, password []byte, salt []byte, iterations int, id byte, size int) []byte {
+	digest := newHash()
+	u := digest.Size()
+	v := digest.BlockSize()
+
+	diversifier := bytes.Repeat([]byte{id}, v)
+	input := append(fillToBlockSize(salt, v), fillToBlockSize(password, v)...)
+
+	key := make([]byte, 0, size)
+	for len(key) < size {
+		digest.Reset()
+		digest.Write(diversifier)
+		digest.Write(input)
+		a := digest.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			digest.Reset()
+			digest.Write(a)
+			a = digest.Sum(a[:0])
+		}
+		key = append(key, a[:min(u, size-len(key))]...)
+
+		b := new(big.Int).SetBytes(fillToBlockSize(a, v))
+		b.Add(b, big.NewInt(1))
+		modulus := new(big.Int).Lsh(big.NewInt(1), uint(v*8))
+		for j := 0; j < len(input); j += v {
+			block := new(big.Int).SetBytes(input[j : j+v])
+			block.Add(block, b).Mod(block, modulus)
+			blockBytes := block.Bytes()
+			copy(input[j:j+v], make([]byte, v-len(blockBytes)))
+			copy(input[j+v-len(blockBytes):j+v], blockBytes)
+		}
+	}
+
+	return key
+}
+
+func fillToBlockSize(value []byte, blockSize int) []byte {
+	if len(value) == 0 {
+		return []byte{}
+	}
+
+	length := blockSize * ((len(value) + blockSize - 1) / blockSize)
+	filled := make([]byte, length)
+	for i := range filled {
+		filled[i] = value[i%len(value)]
+	}
+	return filled
+}
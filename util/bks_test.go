@@ -0,0 +1,90 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+// test vectors taken from the BouncyCastle PKCS12 parameters generator tests
+func TestPkcs12DeriveKey(t *testing.T) {
+	testCases := []struct {
+		salt     string
+		id       byte
+		size     int
+		expected string
+	}{
+		{"0A58CF64530D823F", 1, 24, "8AAAE6297B6CB04642AB5B077851284EB7128F1A2A7FBCA3"},
+		{"0A58CF64530D823F", 2, 8, "79993DFE048D3B76"},
+		{"3D83C0E4546AC140", 3, 20, "8D967D88F6CAA9D714800AB3D48051D63F73A312"},
+	}
+
+	for _, testCase := range testCases {
+		salt, _ := hex.DecodeString(testCase.salt)
+		key := pkcs12DeriveKey(sha1.New, bmpPassword("smeg"), salt, 1, testCase.id, testCase.size)
+		if result := strings.ToUpper(hex.EncodeToString(key)); result != testCase.expected {
+			t.Errorf("Incorrect key derived for id %v, expected %v but was %v", testCase.id, testCase.expected, result)
+		}
+	}
+}
+
+func TestEncodeBksKeystore(t *testing.T) {
+	salt := bytes.Repeat([]byte{7}, bksSaltSize)
+	created := time.Unix(1500000000, 0)
+	certs := []bksCertificate{
+		{alias: "b.cer", der: []byte{0x30, 0x01, 0x02}},
+		{alias: "a.cer", der: []byte{0x30, 0x03}},
+	}
+
+	keystore, err := encodeBksKeystoreWithSalt(certs, "secret", created, salt, 1042)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reader := bytes.NewReader(keystore)
+	var version, saltLength, iterations int32
+	_ = binary.Read(reader, binary.BigEndian, &version)
+	_ = binary.Read(reader, binary.BigEndian, &saltLength)
+	reader.Seek(int64(saltLength), 1)
+	_ = binary.Read(reader, binary.BigEndian, &iterations)
+	if version != bksVersion || saltLength != bksSaltSize || iterations != 1042 {
+		t.Fatalf("Incorrect keystore header: version %v, salt length %v, iterations %v", version, saltLength, iterations)
+	}
+
+	bodyStart := 4 + 4 + bksSaltSize + 4
+	body := keystore[bodyStart : len(keystore)-sha1.Size]
+	macKey := pkcs12DeriveKey(sha1.New, bmpPassword("secret"), salt, 1042, pkcs12MacMaterial, 2)
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), keystore[len(keystore)-sha1.Size:]) {
+		t.Errorf("Keystore MAC does not match the contents")
+	}
+
+	expectedBody := []byte{
+		1, 0, 5, 'a', '.', 'c', 'e', 'r', 0, 0, 1, 0x5d, 0x3e, 0xf7, 0x98, 0x00, 0, 0, 0, 0, 0, 5, 'X', '.', '5', '0', '9', 0, 0, 0, 2, 0x30, 0x03,
+		1, 0, 5, 'b', '.', 'c', 'e', 'r', 0, 0, 1, 0x5d, 0x3e, 0xf7, 0x98, 0x00, 0, 0, 0, 0, 0, 5, 'X', '.', '5', '0', '9', 0, 0, 0, 3, 0x30, 0x01, 0x02,
+		0,
+	}
+	if !bytes.Equal(body, expectedBody) {
+		t.Errorf("Incorrect keystore entries:\n%v\nexpected:\n%v", body, expectedBody)
+	}
+}
@@ -0,0 +1,92 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseCertificate decodes one of the PEM encoded certificates from the configuration zip.
+func parseCertificate(certName string, certContents string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certContents))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, &CertificateError{Name: certName, Err: ErrInvalidCertificate}
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, &CertificateError{Name: certName, Err: fmt.Errorf("%w: %v", ErrInvalidCertificate, err)}
+	}
+	return cert, nil
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the certificate in the colon separated format used by keytool.
+func certificateFingerprint(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.Raw)
+	hexBytes := make([]string, len(hash))
+	for i, b := range hash {
+		hexBytes[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hexBytes, ":")
+}
+
+// ValidateCertificates checks every certificate before it is pinned. Malformed and expired certificates are returned as errors, all of them
+// at once. Certificates that expire within the threshold, leaf certificates and duplicates only result in a warning.
+func ValidateCertificates(config *Config, expiryThreshold time.Duration) error {
+	return validateCertificates(config, expiryThreshold, time.Now())
+}
+
+func validateCertificates(config *Config, expiryThreshold time.Duration, now time.Time) error {
+	var errs []error
+	fingerprints := make(map[string]string)
+
+	for _, certName := range sortedKeys(config.Certs) {
+		cert, err := parseCertificate(certName, config.Certs[certName])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if now.After(cert.NotAfter) {
+			errs = append(errs, &CertificateError{Name: certName, Err: fmt.Errorf("%w on %v", ErrExpiredCertificate, cert.NotAfter.Format(time.RFC3339))})
+		} else if now.Add(expiryThreshold).After(cert.NotAfter) {
+			config.AddWarning(fmt.Sprintf("Certificate '%v' expires on %v", certName, cert.NotAfter.Format(time.RFC3339)))
+		}
+
+		if now.Before(cert.NotBefore) {
+			config.AddWarning(fmt.Sprintf("Certificate '%v' is not valid before %v", certName, cert.NotBefore.Format(time.RFC3339)))
+		}
+
+		if !cert.IsCA {
+			config.AddWarning(fmt.Sprintf("Certificate '%v' (%v) is not a CA certificate, pinning a leaf certificate breaks the app as soon as the "+
+				"server certificate is renewed", certName, cert.Subject.String()))
+		}
+
+		fingerprint := certificateFingerprint(cert)
+		if duplicateName, ok := fingerprints[fingerprint]; ok {
+			config.AddWarning(fmt.Sprintf("Certificate '%v' is a duplicate of certificate '%v'", certName, duplicateName))
+		} else {
+			fingerprints[fingerprint] = certName
+		}
+	}
+
+	return errors.Join(errs...)
+}
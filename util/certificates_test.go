@@ -0,0 +1,55 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateCertificates(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	root := generateTestCertificate(t, "Root CA", now.AddDate(-1, 0, 0), now.AddDate(5, 0, 0), true)
+
+	config := &Config{Certs: map[string]string{
+		"root.cer":      root,
+		"duplicate.cer": root,
+		"expiring.cer":  generateTestCertificate(t, "Expiring CA", now.AddDate(-1, 0, 0), now.AddDate(0, 0, 10), true),
+		"leaf.cer":      generateTestCertificate(t, "onegini.example.com", now.AddDate(-1, 0, 0), now.AddDate(1, 0, 0), false),
+		"expired.cer":   generateTestCertificate(t, "Expired CA", now.AddDate(-2, 0, 0), now.AddDate(0, 0, -1), true),
+		"malformed.cer": "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n",
+	}}
+
+	err := validateCertificates(config, 30*24*time.Hour, now)
+
+	if !errors.Is(err, ErrExpiredCertificate) || !errors.Is(err, ErrInvalidCertificate) {
+		t.Errorf("Incorrect result, the expired and malformed certificates should be reported: %v", err)
+	}
+	if !strings.Contains(err.Error(), "'expired.cer'") || !strings.Contains(err.Error(), "'malformed.cer'") {
+		t.Errorf("Incorrect result, the errors should contain the file names: %v", err)
+	}
+
+	expectedWarnings := []string{"'expiring.cer' expires", "'leaf.cer' (CN=onegini.example.com) is not a CA", "'root.cer' is a duplicate of certificate 'duplicate.cer'"}
+	if len(config.Warnings) != len(expectedWarnings) {
+		t.Fatalf("Incorrect number of warnings: %v", config.Warnings)
+	}
+	for i, warning := range config.Warnings {
+		if !strings.Contains(warning, expectedWarnings[i]) {
+			t.Errorf("Incorrect warning '%v', expected it to contain '%v'", warning, expectedWarnings[i])
+		}
+	}
+}
@@ -0,0 +1,393 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// All files are written through a change set: every write and delete is staged in memory first and reads of a staged file return its
+// staged contents. The changes are written to the project in one go by ApplyChanges, or only printed when running with --dry-run.
+
+const (
+	ChangeCreate = "create"
+	ChangeModify = "modify"
+	ChangeDelete = "delete"
+	ChangeMkdir  = "mkdir"
+	ChangeXcode  = "xcode"
+)
+
+type Change struct {
+	Kind        string
+	Path        string
+	Description string
+	Before      []byte
+	After       []byte
+}
+
+type stagedFile struct {
+	original       []byte
+	originalExists bool
+	contents       []byte
+	deleted        bool
+}
+
+type changeSet struct {
+	files      map[string]*stagedFile
+	filesOrder []string
+	dirs       []string
+	xcodeEdits []string
+}
+
+func (config *Config) changeSet() *changeSet {
+	if config.changes == nil {
+		config.changes = &changeSet{files: make(map[string]*stagedFile)}
+	}
+	return config.changes
+}
+
+// ShareChanges makes the config stage its changes in the change set of the other config, so the changes of several configs, e.g. one per
+// flavor, see each other and are printed and applied together.
+func (config *Config) ShareChanges(other *Config) {
+	config.changes = other.changeSet()
+}
+
+func (config *Config) stagedFile(filePath string) *stagedFile {
+	changes := config.changeSet()
+	filePath = filepath.Clean(filePath)
+
+	if staged, ok := changes.files[filePath]; ok {
+		return staged
+	}
+
+	staged := new(stagedFile)
+	if original, err := ioutil.ReadFile(filePath); err == nil {
+		staged.original = original
+		staged.originalExists = true
+		staged.contents = original
+	} else {
+		staged.deleted = true
+	}
+	changes.files[filePath] = staged
+	changes.filesOrder = append(changes.filesOrder, filePath)
+
+	return staged
+}
+
+func (config *Config) readFile(filePath string) ([]byte, error) {
+	if staged, ok := config.changeSet().files[filepath.Clean(filePath)]; ok {
+		if staged.deleted {
+			return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+		}
+		return staged.contents, nil
+	}
+	return ioutil.ReadFile(filePath)
+}
+
+func (config *Config) fileExists(filePath string) bool {
+	if staged, ok := config.changeSet().files[filepath.Clean(filePath)]; ok {
+		return !staged.deleted
+	}
+	return exists(filePath)
+}
+
+// isStaged reports whether the file is written or deleted by the change set, e.g. by another flavor of the same run.
+func (config *Config) isStaged(filePath string) bool {
+	_, ok := config.changeSet().files[filepath.Clean(filePath)]
+	return ok
+}
+
+// dirExists reports whether the directory exists in the project or contains a staged file or directory.
+func (config *Config) dirExists(dirPath string) bool {
+	if exists(dirPath) {
+		return true
+	}
+	dirPath = filepath.Clean(dirPath)
+	isInDir := func(filePath string) bool {
+		return filePath == dirPath || strings.HasPrefix(filePath, dirPath+string(filepath.Separator))
+	}
+
+	changes := config.changeSet()
+	for _, dir := range changes.dirs {
+		if isInDir(filepath.Clean(dir)) {
+			return true
+		}
+	}
+	for filePath, staged := range changes.files {
+		if !staged.deleted && isInDir(filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (config *Config) writeFile(filePath string, contents []byte) {
+	config.recordChangedFile(filePath)
+	staged := config.stagedFile(filePath)
+	staged.contents = contents
+	staged.deleted = false
+}
+
+func (config *Config) removeFile(filePath string) {
+	config.recordChangedFile(filePath)
+	staged := config.stagedFile(filePath)
+	staged.contents = nil
+	staged.deleted = true
+}
+
+// recordChangedFile records which files were changed through this config, since the change set may be shared with other configs.
+func (config *Config) recordChangedFile(filePath string) {
+	if config.changedFiles == nil {
+		config.changedFiles = make(map[string]bool)
+	}
+	config.changedFiles[filepath.Clean(filePath)] = true
+}
+
+func (config *Config) mkdirAll(dirPath string) {
+	if exists(dirPath) {
+		return
+	}
+	changes := config.changeSet()
+	for _, dir := range changes.dirs {
+		if dir == dirPath {
+			return
+		}
+	}
+	changes.dirs = append(changes.dirs, dirPath)
+}
+
+func (config *Config) recordXcodeEdit(description string) {
+	changes := config.changeSet()
+	changes.xcodeEdits = append(changes.xcodeEdits, description)
+}
+
+// Changes returns the staged changes in the order in which they were first made. Files that end up with their original contents are left out.
+func (config *Config) Changes() (changes []Change) {
+	for _, dir := range config.changeSet().dirs {
+		changes = append(changes, Change{Kind: ChangeMkdir, Path: dir})
+	}
+
+	for _, filePath := range config.changeSet().filesOrder {
+		staged := config.changeSet().files[filePath]
+		change := Change{Path: filePath, Before: staged.original, After: staged.contents}
+		switch {
+		case staged.originalExists && staged.deleted:
+			change.Kind = ChangeDelete
+		case !staged.originalExists && !staged.deleted:
+			change.Kind = ChangeCreate
+		case staged.originalExists && !bytes.Equal(staged.original, staged.contents):
+			change.Kind = ChangeModify
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	for _, description := range config.changeSet().xcodeEdits {
+		changes = append(changes, Change{Kind: ChangeXcode, Description: description})
+	}
+
+	return
+}
+
+type fileBackup struct {
+	path       string
+	backupPath string
+	mode       os.FileMode
+}
+
+// ApplyChanges writes the staged changes to the project. All files that are modified or deleted are backed up to a temporary directory first,
+// when any of the changes cannot be written the changes that were already made are rolled back so the project is left as it was before. The
+// backups are removed once the changes are written or rolled back, when rolling back fails they are kept so the files can be restored by hand.
+func ApplyChanges(config *Config) error {
+	changes := config.Changes()
+
+	backupDir := ""
+	backups := make(map[string]fileBackup)
+	for _, change := range changes {
+		if change.Kind != ChangeModify && change.Kind != ChangeDelete {
+			continue
+		}
+		if len(backupDir) == 0 {
+			var err error
+			if backupDir, err = ioutil.TempDir("", "sdk-configurator-backup-"); err != nil {
+				return fmt.Errorf("could not create a directory for the backups: %w", err)
+			}
+		}
+		backup, err := backupFile(change.Path, filepath.Join(backupDir, strconv.Itoa(len(backups))))
+		if err != nil {
+			_ = os.RemoveAll(backupDir)
+			return fmt.Errorf("could not back up '%v': %w", change.Path, err)
+		}
+		backups[change.Path] = backup
+	}
+
+	var createdDirs []string
+	var appliedChanges []Change
+	for _, change := range changes {
+		var err error
+		switch change.Kind {
+		case ChangeMkdir:
+			err = createDirs(change.Path, &createdDirs)
+		case ChangeCreate, ChangeModify:
+			if err = createDirs(filepath.Dir(change.Path), &createdDirs); err == nil {
+				err = writeChangedFile(change.Path, change.After, backups)
+			}
+		case ChangeDelete:
+			err = os.Remove(change.Path)
+		}
+
+		if err != nil {
+			rollbackErr := rollbackChanges(appliedChanges, change, backups, createdDirs)
+			if rollbackErr != nil {
+				return fmt.Errorf("could not write changes to '%v': %w, rolling back the changes failed as well: %v, the original files are kept in '%v'",
+					change.Path, err, rollbackErr, backupDir)
+			}
+			removeBackups(backupDir)
+			return fmt.Errorf("could not write changes to '%v', all changes have been rolled back: %w", change.Path, err)
+		}
+		appliedChanges = append(appliedChanges, change)
+	}
+
+	removeBackups(backupDir)
+	return nil
+}
+
+// backupFile copies the file to the backup path, so it can be restored even when the configurator is interrupted while writing the changes.
+func backupFile(filePath string, backupPath string) (fileBackup, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fileBackup{}, err
+	}
+	contents, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fileBackup{}, err
+	}
+	if err := ioutil.WriteFile(backupPath, contents, 0600); err != nil {
+		return fileBackup{}, err
+	}
+	return fileBackup{path: filePath, backupPath: backupPath, mode: info.Mode().Perm()}, nil
+}
+
+func removeBackups(backupDir string) {
+	if len(backupDir) > 0 {
+		_ = os.RemoveAll(backupDir)
+	}
+}
+
+func writeChangedFile(filePath string, contents []byte, backups map[string]fileBackup) error {
+	mode := os.ModePerm
+	if backup, ok := backups[filePath]; ok {
+		mode = backup.mode
+	}
+	return ioutil.WriteFile(filePath, contents, mode)
+}
+
+// createDirs creates the directory and its missing parents, every directory that is created is added to createdDirs.
+func createDirs(dirPath string, createdDirs *[]string) error {
+	var missingDirs []string
+	for dir := filepath.Clean(dirPath); !exists(dir); dir = filepath.Dir(dir) {
+		missingDirs = append(missingDirs, dir)
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	for i := len(missingDirs) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missingDirs[i], os.ModePerm); err != nil {
+			return err
+		}
+		*createdDirs = append(*createdDirs, missingDirs[i])
+	}
+	return nil
+}
+
+// rollbackChanges restores the backups of the applied changes and of the change that failed, since that one may have been written partially.
+func rollbackChanges(appliedChanges []Change, failedChange Change, backups map[string]fileBackup, createdDirs []string) error {
+	var errs []error
+	changes := append(appliedChanges, failedChange)
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		switch change.Kind {
+		case ChangeCreate:
+			if _, err := os.Stat(change.Path); err != nil {
+				// the file was never created
+				continue
+			}
+			if err := os.Remove(change.Path); err != nil {
+				errs = append(errs, err)
+			}
+		case ChangeModify, ChangeDelete:
+			backup := backups[change.Path]
+			contents, err := ioutil.ReadFile(backup.backupPath)
+			if err == nil {
+				err = ioutil.WriteFile(backup.path, contents, backup.mode)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for i := len(createdDirs) - 1; i >= 0; i-- {
+		if err := os.Remove(createdDirs[i]); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func PrintChangePlan(config *Config) {
+	changes := config.Changes()
+	if len(changes) == 0 {
+		fmt.Println("DRY RUN: No changes would be made to your application.")
+		return
+	}
+
+	fmt.Print("DRY RUN: The following changes would be made to your application.\n\n")
+	for _, change := range changes {
+		if change.Kind == ChangeXcode {
+			fmt.Printf("%v\t%v\n", change.Kind, change.Description)
+		} else {
+			fmt.Printf("%v\t%v\n", change.Kind, change.Path)
+		}
+	}
+
+	for _, change := range changes {
+		if change.Kind != ChangeCreate && change.Kind != ChangeModify && change.Kind != ChangeDelete {
+			continue
+		}
+
+		fmt.Println("")
+		if isBinary(change.Before) || isBinary(change.After) {
+			fmt.Printf("Binary file %v: %v bytes -> %v bytes\n", change.Path, len(change.Before), len(change.After))
+			continue
+		}
+		fmt.Print(unifiedDiff(change.Path, change.Before, change.After, change.Kind))
+	}
+}
+
+func isBinary(contents []byte) bool {
+	return bytes.IndexByte(contents, 0) >= 0 || !utf8.Valid(contents)
+}
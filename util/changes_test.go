@@ -0,0 +1,144 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChangesAreStagedUntilApplied(t *testing.T) {
+	appDir := t.TempDir()
+	modifiedPath := filepath.Join(appDir, "modified.txt")
+	deletedPath := filepath.Join(appDir, "deleted.txt")
+	recreatedPath := filepath.Join(appDir, "recreated.txt")
+	createdPath := filepath.Join(appDir, "new", "created.txt")
+	_ = os.WriteFile(modifiedPath, []byte("one\ntwo\nthree\n"), 0644)
+	_ = os.WriteFile(deletedPath, []byte("delete me\n"), 0644)
+	_ = os.WriteFile(recreatedPath, []byte("same\n"), 0644)
+
+	config := new(Config)
+	config.writeFile(modifiedPath, []byte("one\n2\nthree\n"))
+	config.removeFile(deletedPath)
+	config.removeFile(recreatedPath)
+	config.writeFile(recreatedPath, []byte("same\n"))
+	config.writeFile(createdPath, []byte("created\n"))
+
+	if contents, _ := config.readFile(modifiedPath); string(contents) != "one\n2\nthree\n" {
+		t.Errorf("Incorrect result, reading a staged file should return the staged contents: %v", string(contents))
+	}
+	if config.fileExists(deletedPath) || !exists(deletedPath) {
+		t.Errorf("Incorrect result, a deleted file should only be removed from the change set")
+	}
+
+	changes := config.Changes()
+	expected := []struct{ kind, path string }{
+		{ChangeModify, modifiedPath},
+		{ChangeDelete, deletedPath},
+		{ChangeCreate, createdPath},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Incorrect number of changes: %v", changes)
+	}
+	for i, change := range changes {
+		if change.Kind != expected[i].kind || change.Path != expected[i].path {
+			t.Errorf("Incorrect change %v, expected %v %v but was %v %v", i, expected[i].kind, expected[i].path, change.Kind, change.Path)
+		}
+	}
+
+	expectedDiff := "--- " + modifiedPath + "\n+++ " + modifiedPath + "\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n"
+	if diff := unifiedDiff(modifiedPath, changes[0].Before, changes[0].After, changes[0].Kind); diff != expectedDiff {
+		t.Errorf("Incorrect diff:\n%v", diff)
+	}
+
+	if err := ApplyChanges(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contents, _ := os.ReadFile(createdPath); string(contents) != "created\n" || exists(deletedPath) {
+		t.Errorf("Incorrect result, the changes should be written to disk")
+	}
+}
+
+func TestApplyChangesRollsBackOnFailure(t *testing.T) {
+	appDir := t.TempDir()
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	modifiedPath := filepath.Join(appDir, "modified.txt")
+	deletedPath := filepath.Join(appDir, "deleted.txt")
+	createdPath := filepath.Join(appDir, "new", "dir", "created.txt")
+	blockingPath := filepath.Join(appDir, "blocking")
+	_ = os.WriteFile(modifiedPath, []byte("original\n"), 0600)
+	_ = os.WriteFile(deletedPath, []byte("delete me\n"), 0644)
+	_ = os.WriteFile(blockingPath, []byte("not a directory\n"), 0644)
+
+	config := new(Config)
+	config.writeFile(modifiedPath, []byte("modified\n"))
+	config.removeFile(deletedPath)
+	config.writeFile(createdPath, []byte("created\n"))
+	// a file cannot be written inside another file, so this change fails after the other ones have been applied
+	config.writeFile(filepath.Join(blockingPath, "failing.txt"), []byte("failing\n"))
+
+	err := ApplyChanges(config)
+	if err == nil || !strings.Contains(err.Error(), "all changes have been rolled back") {
+		t.Fatalf("Incorrect result, applying the changes should fail and be rolled back: %v", err)
+	}
+
+	if contents, _ := os.ReadFile(modifiedPath); string(contents) != "original\n" {
+		t.Errorf("Incorrect result, the modified file should be restored: %v", string(contents))
+	}
+	if info, err := os.Stat(modifiedPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Incorrect result, the file mode of the modified file should be preserved")
+	}
+	if contents, _ := os.ReadFile(deletedPath); string(contents) != "delete me\n" {
+		t.Errorf("Incorrect result, the deleted file should be restored: %v", string(contents))
+	}
+	if exists(filepath.Join(appDir, "new")) {
+		t.Errorf("Incorrect result, the created file and directories should be removed")
+	}
+	if backups, _ := os.ReadDir(tempDir); len(backups) != 0 {
+		t.Errorf("Incorrect result, the backups should be removed after the rollback: %v", backups)
+	}
+}
+
+func TestApplyChangesRemovesBackups(t *testing.T) {
+	appDir := t.TempDir()
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	modifiedPath := filepath.Join(appDir, "modified.txt")
+	_ = os.WriteFile(modifiedPath, []byte("original\n"), 0644)
+
+	config := new(Config)
+	config.writeFile(modifiedPath, []byte("modified\n"))
+	backup, err := backupFile(modifiedPath, filepath.Join(tempDir, "backup"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contents, _ := os.ReadFile(backup.backupPath); string(contents) != "original\n" {
+		t.Errorf("Incorrect result, the backup should contain the original file: %v", string(contents))
+	}
+	_ = os.Remove(backup.backupPath)
+
+	if err := ApplyChanges(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contents, _ := os.ReadFile(modifiedPath); string(contents) != "modified\n" {
+		t.Errorf("Incorrect result, the file should be modified: %v", string(contents))
+	}
+	if backups, _ := os.ReadDir(tempDir); len(backups) != 0 {
+		t.Errorf("Incorrect result, the backups should be removed after the changes are written: %v", backups)
+	}
+}
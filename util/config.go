@@ -30,16 +30,35 @@ import (
 )
 
 type Config struct {
-	Options                  *options
-	Certs                    map[string]string
-	Cordova                  cordovaConfig
-	NativeScript             nativeScriptConfig
-	AndroidManifest          androidManifest
-	AppDir                   string
-	AppTarget                string
-	FlavorName               string
-	ConfigureForCordova      bool
-	ConfigureForNativeScript bool
+	Options                    *options
+	Certs                      map[string]string
+	Cordova                    cordovaConfig
+	NativeScript               nativeScriptConfig
+	ReactNative                reactNativeConfig
+	Capacitor                  capacitorConfig
+	AndroidManifest            androidManifest
+	AppDir                     string
+	AppTarget                  string
+	FlavorName                 string
+	BuildType                  string
+	TemplateDir                string
+	UpdateManifest             bool
+	ManifestActivity           string
+	UpdateInfoPlist            bool
+	AssetLinksCert             string
+	AssetLinksKeystorePassword string
+	AssetLinksKeyAlias         string
+	ConfigureForCordova        bool
+	ConfigureForNativeScript   bool
+	ConfigureForFlutter        bool
+	ConfigureForReactNative    bool
+	ConfigureForCapacitor      bool
+	Warnings                   []string
+	changes                    *changeSet
+	changedFiles               map[string]bool
+	keystoreHash               string
+	assetLinks                 *AssetLinks
+	quietWarnings              bool
 }
 
 type options struct {
@@ -78,76 +97,217 @@ type NS struct {
 	ID string `json:"id"`
 }
 
+type reactNativeConfig struct {
+	Name string
+}
+
+type reactNativeAppJson struct {
+	Name string `json:"name"`
+	Expo struct {
+		Name string `json:"name"`
+	} `json:"expo"`
+}
+
+type capacitorConfig struct {
+	AppId   string `json:"appId"`
+	AppName string `json:"appName"`
+	Cordova struct {
+		Preferences map[string]string `json:"preferences"`
+	} `json:"cordova"`
+}
+
 type androidManifest struct {
 	PackageID string `xml:"package,attr"`
 }
 
-func ParseConfig(appDir string, configPath string) (config *Config) {
-	config = new(Config)
+func ParseConfig(appDir string, configPath string) (config *Config, err error) {
+	if config, err = ParseTsZip(configPath); err != nil {
+		return nil, err
+	}
+
+	if config.AppDir, err = config.resolveAppDirPath(appDir); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// ParseTsZip only reads the Token Server configuration zip, the returned config is not bound to an application project.
+func ParseTsZip(configPath string) (*Config, error) {
+	config, err := ReadTsZip(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := VerifyTsZipContents(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ReadTsZip reads the Token Server configuration zip like ParseTsZip, but does not verify that it contains the required information, so that an
+// incomplete zip can be inspected as well.
+func ReadTsZip(configPath string) (*Config, error) {
+	config := new(Config)
 	config.Certs = make(map[string]string)
 
 	if len(configPath) == 0 {
-		fmt.Print("ERROR: No Token Server configuration provided. Provide one using 'sdk-configurator <platform> -c <config-zip-location>'\n\n")
-		fmt.Print("execute 'sdk-configurator --help' to see how to use the configurator\n")
-		os.Exit(1)
+		return nil, ErrMissingConfigZip
 	}
 
-	config.AppDir = config.resolveAppDirPath(appDir)
-	parseTsZip(configPath, config)
+	if err := parseTsZip(configPath, config); err != nil {
+		return nil, err
+	}
 
-	return
+	return config, nil
 }
 
-func ParseCordovaConfig(config *Config) {
+func ParseCordovaConfig(config *Config) error {
 	values := cordovaConfig{}
 
 	configXml, err := ioutil.ReadFile(path.Join(config.AppDir, "config.xml"))
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the Cordova config.xml: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("cannot read the Cordova config.xml: %w", err)
 	}
 
 	err = xml.Unmarshal(configXml, &values)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the Cordova config.xml: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("cannot read the Cordova config.xml: %w", err)
 	}
 
 	config.Cordova = values
+	return nil
 }
 
-func ParseNativeScriptConfig(config *Config) {
-	jsonFile, err := os.Open("package.json")
-	// if we os.Open returns an error then handle it
+func ParseNativeScriptConfig(config *Config) error {
+	values := nativeScriptConfig{}
+
+	packageJson, err := ioutil.ReadFile(path.Join(config.AppDir, "package.json"))
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the NativeScript package.json: %v\n", err.Error()))
+		return fmt.Errorf("cannot read the NativeScript package.json: %w", err)
 	}
-	defer jsonFile.Close()
 
-	byteValue, _ := ioutil.ReadAll(jsonFile)
-	var nsConfig nativeScriptConfig
-	json.Unmarshal(byteValue, &nsConfig)
+	err = json.Unmarshal(packageJson, &values)
+	if err != nil {
+		return fmt.Errorf("cannot read the NativeScript package.json: %w", err)
+	}
+
+	config.NativeScript = values
+	return nil
+}
 
-	config.NativeScript = nsConfig
+// VerifyFlutterProject verifies that the app dir contains the pubspec.yaml of a Flutter app, i.e. one that depends on the Flutter SDK.
+func VerifyFlutterProject(config *Config) error {
+	pubspec, err := ioutil.ReadFile(path.Join(config.AppDir, "pubspec.yaml"))
+	if err != nil {
+		return fmt.Errorf("cannot read the Flutter pubspec.yaml: %w", err)
+	}
+
+	if !regexp.MustCompile(`(?m)^\s+sdk:\s*['"]?flutter['"]?\s*(#.*)?$`).Match(pubspec) {
+		return fmt.Errorf("the pubspec.yaml does not depend on the Flutter SDK, it is not the pubspec.yaml of a Flutter app")
+	}
+	return nil
 }
 
-func ParseAndroidManifest(config *Config) {
+// ParseReactNativeConfig reads the name of the React Native app from the app.json in the app dir, or from the package.json when there is no
+// app.json.
+func ParseReactNativeConfig(config *Config) error {
+	var appJson reactNativeAppJson
+	appJsonPath := path.Join(config.AppDir, "app.json")
+	if contents, err := ioutil.ReadFile(appJsonPath); err == nil {
+		if err := json.Unmarshal(contents, &appJson); err != nil {
+			return fmt.Errorf("cannot read the React Native app.json: %w", err)
+		}
+	} else if contents, err := ioutil.ReadFile(path.Join(config.AppDir, "package.json")); err == nil {
+		if err := json.Unmarshal(contents, &appJson); err != nil {
+			return fmt.Errorf("cannot read the React Native package.json: %w", err)
+		}
+	} else {
+		return fmt.Errorf("cannot read the React Native app.json or package.json: %w", err)
+	}
+
+	name := appJson.Name
+	if len(name) == 0 {
+		name = appJson.Expo.Name
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("cannot read the React Native app name: neither the app.json nor the package.json contains a name")
+	}
+
+	config.ReactNative = reactNativeConfig{Name: name}
+	return nil
+}
+
+// ParseCapacitorConfig reads the app id from the capacitor.config.json in the app dir, or from the capacitor.config.ts when the project uses a
+// TypeScript config. Only string literals are supported in a TypeScript config.
+func ParseCapacitorConfig(config *Config) error {
+	var values capacitorConfig
+	if contents, err := ioutil.ReadFile(path.Join(config.AppDir, "capacitor.config.json")); err == nil {
+		if err := json.Unmarshal(contents, &values); err != nil {
+			return fmt.Errorf("cannot read the Capacitor capacitor.config.json: %w", err)
+		}
+	} else if contents, err := ioutil.ReadFile(path.Join(config.AppDir, "capacitor.config.ts")); err == nil {
+		values = parseCapacitorTsConfig(contents)
+	} else {
+		return fmt.Errorf("cannot read the Capacitor capacitor.config.json or capacitor.config.ts: %w", err)
+	}
+
+	if len(values.AppId) == 0 {
+		return fmt.Errorf("cannot read the Capacitor config: it does not contain an appId")
+	}
+
+	config.Capacitor = values
+	return nil
+}
+
+func parseCapacitorTsConfig(contents []byte) (values capacitorConfig) {
+	stringProperty := func(name string) string {
+		matches := regexp.MustCompile(`\b` + name + `\s*:\s*['"\x60]([^'"\x60]+)['"\x60]`).FindSubmatch(contents)
+		if matches == nil {
+			return ""
+		}
+		return string(matches[1])
+	}
+
+	values.AppId = stringProperty("appId")
+	values.AppName = stringProperty("appName")
+	if webView := stringProperty("OneginiWebView"); len(webView) > 0 {
+		values.Cordova.Preferences = map[string]string{"OneginiWebView": webView}
+	}
+	return
+}
+
+func ParseAndroidManifest(config *Config) error {
 	values := androidManifest{}
 
 	manifestPath := config.getAndroidManifestPath()
 	manifestXml, err := ioutil.ReadFile(manifestPath)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the Android Manifest: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("cannot read the Android Manifest: %w", err)
 	}
 
 	err = xml.Unmarshal(manifestXml, &values)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot read the Android Manifest: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("cannot read the Android Manifest: %w", err)
 	}
 
 	config.AndroidManifest = values
+	return nil
+}
+
+// AddWarning prints the warning to stderr and records it so that it can be included in the JSON output. Warnings are only recorded while
+// they are quiet, e.g. while a config is inspected and its warnings are reported as findings instead.
+func (config *Config) AddWarning(warning string) {
+	for _, existingWarning := range config.Warnings {
+		if existingWarning == warning {
+			return
+		}
+	}
+	config.Warnings = append(config.Warnings, warning)
+	if !config.quietWarnings {
+		_, _ = os.Stderr.WriteString(fmt.Sprintf("WARNING: %v\n", warning))
+	}
 }
 
 func SetAppTarget(appTarget string, config *Config) {
@@ -158,31 +318,58 @@ func SetFlavorName(flavorName string, config *Config) {
 	config.FlavorName = flavorName
 }
 
-func parseTsZip(path string, config *Config) {
+func SetBuildType(buildType string, config *Config) {
+	config.BuildType = buildType
+}
+
+func SetTemplateDir(templateDir string, config *Config) {
+	config.TemplateDir = templateDir
+}
+
+func SetInfoPlistUpdate(updateInfoPlist bool, config *Config) {
+	config.UpdateInfoPlist = updateInfoPlist
+}
+
+func SetManifestUpdate(updateManifest bool, manifestActivity string, config *Config) {
+	config.UpdateManifest = updateManifest
+	config.ManifestActivity = manifestActivity
+}
+
+func SetAssetLinksCertificate(cert string, keystorePassword string, keyAlias string, config *Config) {
+	config.AssetLinksCert = cert
+	config.AssetLinksKeystorePassword = keystorePassword
+	config.AssetLinksKeyAlias = keyAlias
+}
+
+func parseTsZip(path string, config *Config) error {
 	readCloser, err := zip.OpenReader(path)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: could not read Token Server configuration zip: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("could not read Token Server configuration zip: %w", err)
 	}
 
 	defer readCloser.Close()
 
 	for _, file := range readCloser.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
 		openedFile, err := file.Open()
 		if err != nil {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: could not read the contents of Token Server configuration zip: %v\n", err.Error()))
-			os.Exit(1)
+			return fmt.Errorf("could not read the contents of Token Server configuration zip: %w", err)
 		}
 
 		if file.Name == "config.json" {
-			config.Options, _ = parseTsJson(openedFile)
+			if config.Options, err = parseTsJson(openedFile); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidConfigZip, err)
+			}
 			// Don't use the filepath.Separator in the statement below because the filename always contains the forward / regardless of the
 			// platform the configurator is run on
 		} else if strings.HasPrefix(file.Name, "certificates/") {
 			config.Certs[strings.Replace(file.Name, "certificates"+string(filepath.Separator), "", -1)] = readCert(openedFile)
 		}
 	}
-	VerifyTsZipContents(config)
+	return nil
 }
 
 func parseTsJson(reader io.Reader) (v *options, err error) {
@@ -206,33 +393,29 @@ func getPackageIdentifierFromConfig(config *Config) string {
 	}
 }
 
-func VerifyTsZipContents(config *Config) {
+func VerifyTsZipContents(config *Config) error {
 	if config.Options == nil {
-		os.Stderr.WriteString(fmt.Sprintln("ERROR: The provided configuration zip does not contain the required information. Is the supplied archive a valid Token " +
-			"Server configuration zip?"))
-		os.Exit(1)
+		return ErrInvalidConfigZip
 	}
 
 	if config.Options.ResourceGatewayUris == nil || len(config.Options.ResourceGatewayUris) == 0 {
-		os.Stderr.WriteString(fmt.Sprint("ERROR: No resource gateway URI is specified in the configuration zip. Please check the Token Server configuration.\n" +
-			"See the following link for more info: https://docs.onegini.com/public/token-server/topics/general-app-config/resource-gateway/resource-gateway.html\n"))
-		os.Exit(1)
+		return ErrMissingResourceGateway
 	}
 
 	if config.Certs == nil || len(config.Certs) == 0 {
-		os.Stderr.WriteString(fmt.Sprintln("ERROR: Does the Token Server configuration zip contain certificates?"))
-		os.Exit(1)
+		return ErrMissingCertificates
 	}
+
+	return nil
 }
 
-func (config *Config) resolveAppDirPath(appDir string) string {
+func (config *Config) resolveAppDirPath(appDir string) (string, error) {
 	absAppDirPath, err := filepath.Abs(appDir)
 
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could resolve App dir '%v' into absolute path", config.AppDir))
-		os.Exit(1)
+		return "", fmt.Errorf("could not resolve App dir '%v' into absolute path: %w", appDir, err)
 	}
-	return absAppDirPath
+	return absAppDirPath, nil
 }
 
 // Android Paths
@@ -276,10 +459,35 @@ func getNativeScriptAndroidClasspath(config *Config) string {
 	return path.Join(getNativeScriptAndroidPlatformPath(config), "java", path.Join(strings.Split(config.AndroidManifest.PackageID, ".")...))
 }
 
+func getFlutterAndroidProjPath(config *Config) string {
+	return path.Join(config.AppDir, "android")
+}
+
+func getReactNativeAndroidProjPath(config *Config) string {
+	return path.Join(config.AppDir, "android")
+}
+
+func getCapacitorAndroidProjPath(config *Config) string {
+	return path.Join(config.AppDir, "android")
+}
+
+// getAndroidModulePath returns the directory of the Gradle module that contains the application sources
+func (config *Config) getAndroidModulePath() string {
+	if config.ConfigureForFlutter {
+		return path.Join(getFlutterAndroidProjPath(config), config.AppTarget)
+	} else if config.ConfigureForReactNative {
+		return path.Join(getReactNativeAndroidProjPath(config), config.AppTarget)
+	} else if config.ConfigureForCapacitor {
+		return path.Join(getCapacitorAndroidProjPath(config), config.AppTarget)
+	}
+	return path.Join(config.AppDir, config.AppTarget)
+}
+
+// getDefaultAndroidPlatformPath returns the source set of the flavor and the build type, e.g. src/freeStagingDebug, or the main source set
 func getDefaultAndroidPlatformPath(config *Config, useFlavor bool) string {
-	srcPath := path.Join(config.AppDir, config.AppTarget, "src")
-	if useFlavor && len(config.FlavorName) > 0 {
-		return path.Join(srcPath, config.FlavorName)
+	srcPath := path.Join(config.getAndroidModulePath(), "src")
+	if sourceSetName := config.getAndroidSourceSetName(); useFlavor && len(sourceSetName) > 0 {
+		return path.Join(srcPath, sourceSetName)
 	} else {
 		return path.Join(srcPath, "main")
 	}
@@ -317,9 +525,7 @@ func getPlatformSpecificAndroidClasspathPath(config *Config) string {
 
 func (config *Config) getAndroidKeystorePath() string {
 	androidRawPath := path.Join(getPlatformSpecificAndroidPlatformPath(config, true), "res", "raw")
-	if exists(androidRawPath) == false {
-		os.MkdirAll(androidRawPath, os.ModePerm)
-	}
+	config.mkdirAll(androidRawPath)
 
 	return path.Join(androidRawPath, "keystore.bks")
 }
@@ -328,7 +534,57 @@ func (config *Config) getAndroidManifestPath() string {
 	return path.Join(getPlatformSpecificAndroidPlatformPath(config, false), "AndroidManifest.xml")
 }
 
+// getAndroidKotlinSourcePath returns the directory of the Kotlin sources of the source set when the module keeps them apart from the Java
+// sources: a directory that the Gradle file adds to the source set, or the kotlin directory that the Kotlin plugin adds by default. It is empty
+// when the Kotlin sources are in the java directory. A flavor or build type without sources of its own follows the main source set.
+func (config *Config) getAndroidKotlinSourcePath() string {
+	if config.ConfigureForCordova || config.ConfigureForNativeScript {
+		return ""
+	}
+
+	platformPath := getDefaultAndroidPlatformPath(config, true)
+	if kotlinPath := config.androidSourceSetKotlinPath(platformPath); len(kotlinPath) > 0 {
+		return kotlinPath
+	}
+
+	mainPlatformPath := getDefaultAndroidPlatformPath(config, false)
+	if platformPath == mainPlatformPath || config.dirExists(path.Join(platformPath, "java")) {
+		return ""
+	}
+	if len(config.androidSourceSetKotlinPath(mainPlatformPath)) > 0 {
+		return path.Join(platformPath, "kotlin")
+	}
+	return ""
+}
+
+// androidSourceSetKotlinPath returns the Kotlin source directory that the source set has of its own, it is empty when the source set has none.
+func (config *Config) androidSourceSetKotlinPath(platformPath string) string {
+	if buildFile, err := config.loadAndroidBuildFile(); err == nil {
+		if kotlinDir := buildFile.kotlinSourceDir(path.Base(platformPath)); len(kotlinDir) > 0 {
+			if path.IsAbs(kotlinDir) {
+				return kotlinDir
+			}
+			return path.Join(config.getAndroidModulePath(), kotlinDir)
+		}
+	}
+
+	if kotlinPath := path.Join(platformPath, "kotlin"); config.dirExists(kotlinPath) {
+		return kotlinPath
+	}
+	return ""
+}
+
+// getAndroidConfigModelKotlinPath returns the path of the Kotlin config model, which is placed with the Kotlin sources of the module.
 func (config *Config) getAndroidConfigModelKotlinPath() string {
+	modelPath := config.getAndroidConfigModelKotlinPathInJavaDir()
+	if kotlinSourcePath := config.getAndroidKotlinSourcePath(); len(kotlinSourcePath) > 0 {
+		javaSourcePath := path.Join(getDefaultAndroidPlatformPath(config, true), "java")
+		modelPath = path.Join(kotlinSourcePath, strings.TrimPrefix(modelPath, javaSourcePath))
+	}
+	return modelPath
+}
+
+func (config *Config) getAndroidConfigModelKotlinPathInJavaDir() string {
 	modelPath := path.Join(getPlatformSpecificAndroidClasspathPath(config), "OneginiConfigModel.kt")
 	// if modelPath has no package name, check namespace property in build.gradle
 	if strings.HasSuffix(modelPath, "java/OneginiConfigModel.kt") {
@@ -348,24 +604,25 @@ func (config *Config) getAndroidConfigModelJavaPath() string {
 	return modelPath
 }
 
-func (config *Config) getAndroidClasspathPath() string {
-	return path.Join(getPlatformSpecificAndroidClasspathPath(config))
+func (config *Config) getAndroidConfigModelPath(generateJavaConfigModel bool) string {
+	if generateJavaConfigModel {
+		return config.getAndroidConfigModelJavaPath()
+	}
+	return config.getAndroidConfigModelKotlinPath()
 }
 
 func (config *Config) getAndroidNamespacePath() string {
-	gradleFilePath := path.Join(config.AppDir, config.AppTarget, "build.gradle")
-	gradleContent, err := os.ReadFile(gradleFilePath)
+	buildFile, err := config.loadAndroidBuildFile()
 	if err != nil {
-		fmt.Println("Error during reading gradle file", err)
+		config.AddWarning(fmt.Sprintf("Could not read the Gradle file: %v", err))
+	} else if namespace := buildFile.namespace(); len(namespace) > 0 {
+		return namespace
 	}
 
-	pattern := `(?:namespace\s*=\s*|namespace\s+)['"]([^'"]+)['"]`
-	namespaceRegexMatches := regexp.MustCompile(pattern).FindStringSubmatch(string(gradleContent))
-
-	if len(namespaceRegexMatches) > 0 && namespaceRegexMatches[1] != "" {
-		return namespaceRegexMatches[1]
+	if config.ConfigureForCapacitor && len(config.Capacitor.AppId) > 0 {
+		return config.Capacitor.AppId
 	}
-	fmt.Println("Namespace property not found in build.gradle file")
+	config.AddWarning("Namespace property not found in build.gradle or build.gradle.kts file")
 	return ""
 }
 
@@ -387,6 +644,31 @@ func getNativeScriptIosSrcPath(config *Config) string {
 	return path.Join(getNativeScriptIosProjPath(config), config.AppTarget)
 }
 
+func getFlutterIosProjPath(config *Config) string {
+	return path.Join(config.AppDir, "ios")
+}
+
+func getFlutterIosSrcPath(config *Config) string {
+	return path.Join(getFlutterIosProjPath(config), config.AppTarget)
+}
+
+func getReactNativeIosProjPath(config *Config) string {
+	return path.Join(config.AppDir, "ios")
+}
+
+func getReactNativeIosSrcPath(config *Config) string {
+	return path.Join(getReactNativeIosProjPath(config), config.AppTarget)
+}
+
+// Capacitor keeps the Xcode project and the app sources in the 'App' directory of the iOS project
+func getCapacitorIosProjPath(config *Config) string {
+	return path.Join(config.AppDir, "ios", "App")
+}
+
+func getCapacitorIosSrcPath(config *Config) string {
+	return path.Join(getCapacitorIosProjPath(config), config.AppTarget)
+}
+
 func getNativeIosProjPath(config *Config) string {
 	return config.AppDir
 }
@@ -396,6 +678,12 @@ func getPlatformSpecificIosProjPath(config *Config) string {
 		return getCordovaIosProjPath(config)
 	} else if config.ConfigureForNativeScript {
 		return getNativeScriptIosProjPath(config)
+	} else if config.ConfigureForFlutter {
+		return getFlutterIosProjPath(config)
+	} else if config.ConfigureForReactNative {
+		return getReactNativeIosProjPath(config)
+	} else if config.ConfigureForCapacitor {
+		return getCapacitorIosProjPath(config)
 	} else {
 		return getNativeIosProjPath(config)
 	}
@@ -406,26 +694,33 @@ func getPlatformSpecificIosSrcPath(config *Config) string {
 		return getCordovaIosSrcPath(config)
 	} else if config.ConfigureForNativeScript {
 		return getNativeScriptIosSrcPath(config)
+	} else if config.ConfigureForFlutter {
+		return getFlutterIosSrcPath(config)
+	} else if config.ConfigureForReactNative {
+		return getReactNativeIosSrcPath(config)
+	} else if config.ConfigureForCapacitor {
+		return getCapacitorIosSrcPath(config)
 	} else {
 		return getNativeIosProjPath(config)
 	}
 }
 
-func (config *Config) getIosXcodeProjPath() string {
+func (config *Config) getIosXcodeProjPath() (string, error) {
 	files, err := filepath.Glob(path.Join(getPlatformSpecificIosProjPath(config), "*.xcodeproj"))
 
 	if err != nil || len(files) == 0 {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not find an Xcode project directory (.xcodeproj). Are you sure that '%v' contains one?\n", getPlatformSpecificIosProjPath(config)))
-		os.Exit(1)
+		return "", fmt.Errorf("%w: are you sure that '%v' contains one?", ErrXcodeProjectNotFound, getPlatformSpecificIosProjPath(config))
 	}
 
 	if len(files) > 1 {
-		os.Stderr.WriteString(fmt.Sprint("ERROR: Found multiple Xcode project directories (.xcodeproj). The SDK configurator currently only support a " +
-			"single xcodeproj directory."))
-		os.Exit(1)
+		xcodeProjPath, found := selectAppXcodeProj(files, config.AppTarget)
+		if !found {
+			return "", fmt.Errorf("%w: found %v in '%v'", ErrMultipleXcodeProjects, strings.Join(baseNames(files), ", "), getPlatformSpecificIosProjPath(config))
+		}
+		return xcodeProjPath, nil
 	}
 
-	return files[0]
+	return files[0], nil
 }
 
 func (config *Config) getIosConfigModelPath() string {
@@ -454,3 +749,7 @@ func (config *Config) getIosConfigModelPathMFile() string {
 func (config *Config) getIosConfigModelPathHFile() string {
 	return path.Join(config.getIosConfigModelPath(), "OneginiConfigModel.h")
 }
+
+func (config *Config) getIosConfigModelPathSwiftFile() string {
+	return path.Join(config.getIosConfigModelPath(), "OneginiConfigModel.swift")
+}
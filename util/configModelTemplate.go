@@ -0,0 +1,148 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/onewelcome/sdk-configurator/data"
+	"github.com/onewelcome/sdk-configurator/version"
+)
+
+// configModelData contains the values that are available in the config model templates. Values are inserted as source code string literals
+// with the string and strings template functions, which escape them for the language of the template.
+type configModelData struct {
+	ConfiguratorVersion string
+	PackageName         string
+	AppIdentifier       string
+	AppVersion          string
+	RedirectUrl         string
+	TokenServerUri      string
+	MaxPinFailures      int
+	ResourceBaseUrl     string
+	ResourceBaseUrls    []string
+	KeystoreHash        string
+	ServerPublicKey     string
+	ServerType          string
+	ServerVersion       string
+	Certificates        []string
+}
+
+// stringLiteralFormats contains how a string literal is written for each config model language, by file extension. Java processes unicode
+// escapes before parsing string literals, so control characters are written as octal escapes instead.
+var stringLiteralFormats = map[string]stringLiteralFormat{
+	".kt":    {escapes: map[rune]string{'\\': `\\`, '"': `\"`, '$': `\$`, '\n': `\n`, '\r': `\r`, '\t': `\t`}, control: `\u%04x`},
+	".java":  {escapes: map[rune]string{'\\': `\\`, '"': `\"`, '\n': `\n`, '\r': `\r`, '\t': `\t`}, control: `\%03o`},
+	".m":     {prefix: "@", escapes: map[rune]string{'\\': `\\`, '"': `\"`, '\n': `\n`, '\r': `\r`, '\t': `\t`}, control: `\%03o`},
+	".h":     {prefix: "@", escapes: map[rune]string{'\\': `\\`, '"': `\"`, '\n': `\n`, '\r': `\r`, '\t': `\t`}, control: `\%03o`},
+	".swift": {escapes: map[rune]string{'\\': `\\`, '"': `\"`, '\n': `\n`, '\r': `\r`, '\t': `\t`}, control: `\u{%x}`},
+}
+
+type stringLiteralFormat struct {
+	prefix  string
+	escapes map[rune]string
+	control string
+}
+
+func (format stringLiteralFormat) quote(value string) string {
+	var literal strings.Builder
+	literal.WriteString(format.prefix + `"`)
+	for _, r := range value {
+		if escaped, ok := format.escapes[r]; ok {
+			literal.WriteString(escaped)
+		} else if r < 0x20 || r == 0x7f {
+			literal.WriteString(fmt.Sprintf(format.control, r))
+		} else {
+			literal.WriteRune(r)
+		}
+	}
+	literal.WriteString(`"`)
+	return literal.String()
+}
+
+func (format stringLiteralFormat) quoteAll(values []string) string {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = format.quote(value)
+	}
+	return strings.Join(literals, ", ")
+}
+
+func newConfigModelData(config *Config) configModelData {
+	return configModelData{
+		ConfiguratorVersion: version.Version,
+		AppIdentifier:       config.Options.AppID,
+		AppVersion:          config.Options.AppVersion,
+		RedirectUrl:         config.Options.RedirectUrl,
+		TokenServerUri:      config.Options.TokenServerUri,
+		MaxPinFailures:      config.Options.MaxPinFailures,
+		ResourceBaseUrl:     config.Options.ResourceGatewayUris[0],
+		ResourceBaseUrls:    config.Options.ResourceGatewayUris,
+		ServerPublicKey:     config.Options.ServerPublicKey.Encoded,
+		ServerType:          config.Options.ServerType,
+		ServerVersion:       config.Options.ServerVersion,
+	}
+}
+
+// renderConfigModel renders the config model template with the given name, e.g. OneginiConfigModel.kt. A template with the same name in the
+// template dir overrides the one that is bundled with the configurator.
+func renderConfigModel(config *Config, templateName string, modelData configModelData) ([]byte, error) {
+	templateContents, err := loadConfigModelTemplate(config, templateName)
+	if err != nil {
+		return nil, err
+	}
+
+	format, ok := stringLiteralFormats[path.Ext(templateName)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported config model template '%v'", templateName)
+	}
+	funcs := template.FuncMap{"string": format.quote, "strings": format.quoteAll}
+
+	modelTemplate, err := template.New(templateName).Funcs(funcs).Option("missingkey=error").Parse(string(templateContents))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config model template '%v': %w", templateName, err)
+	}
+
+	var model bytes.Buffer
+	if err := modelTemplate.Execute(&model, modelData); err != nil {
+		return nil, fmt.Errorf("could not render config model template '%v': %w", templateName, err)
+	}
+	return model.Bytes(), nil
+}
+
+func loadConfigModelTemplate(config *Config, templateName string) ([]byte, error) {
+	if len(config.TemplateDir) > 0 {
+		templatePath := filepath.Join(config.TemplateDir, templateName)
+		if exists(templatePath) {
+			templateContents, err := ioutil.ReadFile(templatePath)
+			if err != nil {
+				return nil, fmt.Errorf("could not read config model template '%v': %w", templatePath, err)
+			}
+			return templateContents, nil
+		}
+	}
+
+	templateContents, err := data.Asset("lib/" + templateName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config model in assets: %w", err)
+	}
+	return templateContents, nil
+}
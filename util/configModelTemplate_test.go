@@ -0,0 +1,128 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files in testdata")
+
+func testConfigModelData() configModelData {
+	return configModelData{
+		ConfiguratorVersion: "v0.0.0",
+		PackageName:         "com.example.app",
+		AppIdentifier:       `Example "App"`,
+		AppVersion:          "1.0.0",
+		RedirectUrl:         "oneginiexample://loginsuccess",
+		TokenServerUri:      `https://onegini.example.com/$path\with\backslashes`,
+		MaxPinFailures:      3,
+		ResourceBaseUrl:     "https://rs.example.com/resources",
+		ResourceBaseUrls:    []string{"https://rs.example.com/resources", "https://rs2.example.com/api"},
+		KeystoreHash:        "46ed59ae6049a0027c26ba5caa62eeeb32810e58c92cadc04c6fe59a76d13edf",
+		ServerPublicKey:     "MIIBIjANBgkq",
+		ServerType:          "access",
+		ServerVersion:       "12.0.0\n",
+		Certificates:        []string{"MIIDDzCCAfeg", "MIIDDzCCAfeh"},
+	}
+}
+
+// should render every bundled template exactly as the golden file in testdata, run with -update to regenerate them
+func TestRenderConfigModelGoldenFiles(t *testing.T) {
+	for _, templateName := range []string{"OneginiConfigModel.kt", "OneginiConfigModel.java", "OneginiConfigModel.m", "OneginiConfigModel.h", "OneginiConfigModel.swift"} {
+		model, err := renderConfigModel(new(Config), templateName, testConfigModelData())
+		if err != nil {
+			t.Fatalf("Unexpected error rendering %v: %v", templateName, err)
+		}
+
+		goldenPath := filepath.Join("testdata", templateName+".golden")
+		if *updateGoldenFiles {
+			if err := os.WriteFile(goldenPath, model, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		expected, err := os.ReadFile(goldenPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(model) != string(expected) {
+			t.Errorf("Incorrect result for %v:\n%v", templateName, string(model))
+		}
+	}
+}
+
+func TestRenderConfigModelWithSeveralResourceGateways(t *testing.T) {
+	config := &Config{Options: &options{ResourceGatewayUris: []string{"https://rs1.example.com", "https://rs2.example.com/api", "https://rs3.example.com"}}}
+	expected := map[string]string{
+		"OneginiConfigModel.kt":    `val resourceBaseUrls: List<String> = listOf("https://rs1.example.com", "https://rs2.example.com/api", "https://rs3.example.com")`,
+		"OneginiConfigModel.java":  `Arrays.asList("https://rs1.example.com", "https://rs2.example.com/api", "https://rs3.example.com");`,
+		"OneginiConfigModel.m":     `return @[@"https://rs1.example.com", @"https://rs2.example.com/api", @"https://rs3.example.com"];`,
+		"OneginiConfigModel.h":     `+ (NSArray *)resourceBaseURLs;`,
+		"OneginiConfigModel.swift": `return ["https://rs1.example.com", "https://rs2.example.com/api", "https://rs3.example.com"]`,
+	}
+
+	for templateName, expectedLine := range expected {
+		model, err := renderConfigModel(config, templateName, newConfigModelData(config))
+		if err != nil {
+			t.Fatalf("Unexpected error rendering %v: %v", templateName, err)
+		}
+		if !strings.Contains(string(model), expectedLine) {
+			t.Errorf("Incorrect result for %v, expected every resource gateway in:\n%v", templateName, string(model))
+		}
+	}
+}
+
+func TestRenderConfigModelWithOverrideTemplate(t *testing.T) {
+	templateDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(templateDir, "OneginiConfigModel.kt"), []byte("val appIdentifier = {{string .AppIdentifier}}\n"), 0644)
+	config := &Config{TemplateDir: templateDir}
+
+	model, err := renderConfigModel(config, "OneginiConfigModel.kt", testConfigModelData())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(model) != "val appIdentifier = \"Example \\\"App\\\"\"\n" {
+		t.Errorf("Incorrect result, the override template should be used: %v", string(model))
+	}
+
+	// templates that are not overridden still come from the assets
+	if _, err := renderConfigModel(config, "OneginiConfigModel.java", testConfigModelData()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	_ = os.WriteFile(filepath.Join(templateDir, "OneginiConfigModel.kt"), []byte("val appIdentifier = {{.UnknownValue}}\n"), 0644)
+	if _, err := renderConfigModel(config, "OneginiConfigModel.kt", testConfigModelData()); err == nil {
+		t.Errorf("Incorrect result, an invalid template should result in an error")
+	}
+}
+
+func TestStringLiteralEscaping(t *testing.T) {
+	value := "a\"b\\c$d\ne\x01"
+	expected := map[string]string{
+		".kt":    `"a\"b\\c\$d\ne\u0001"`,
+		".java":  `"a\"b\\c$d\ne\001"`,
+		".m":     `@"a\"b\\c$d\ne\001"`,
+		".swift": `"a\"b\\c$d\ne\u{1}"`,
+	}
+	for extension, literal := range expected {
+		if result := stringLiteralFormats[extension].quote(value); result != literal {
+			t.Errorf("Incorrect %v string literal, expected %v but was %v", extension, literal, result)
+		}
+	}
+}
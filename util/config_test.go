@@ -0,0 +1,146 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyFlutterProject(t *testing.T) {
+	appDir := t.TempDir()
+	config := &Config{AppDir: appDir}
+	if err := VerifyFlutterProject(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for a missing pubspec.yaml")
+	}
+
+	pubspec := "# An example package\nname: 'example_package'\ndescription: An example package\n\ndependencies:\n  http: ^1.2.0\n"
+	_ = os.WriteFile(filepath.Join(appDir, "pubspec.yaml"), []byte(pubspec), 0644)
+	if err := VerifyFlutterProject(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for a pubspec.yaml without the Flutter SDK")
+	}
+
+	pubspec = "# An example app\nname: 'example_app' # the package name\ndescription: An example app\n\ndependencies:\n  flutter:\n    sdk: flutter\n"
+	_ = os.WriteFile(filepath.Join(appDir, "pubspec.yaml"), []byte(pubspec), 0644)
+	if err := VerifyFlutterProject(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestFlutterPaths(t *testing.T) {
+	config := &Config{AppDir: "/app", ConfigureForFlutter: true, AndroidManifest: androidManifest{PackageID: "com.example.app"}}
+
+	config.AppTarget = "app"
+	config.FlavorName = "dev"
+	if result := getPlatformSpecificAndroidPlatformPath(config, true); result != "/app/android/app/src/dev" {
+		t.Errorf("Incorrect result, Android platform path was '%v'", result)
+	}
+	if result := config.getAndroidManifestPath(); result != "/app/android/app/src/main/AndroidManifest.xml" {
+		t.Errorf("Incorrect result, Android manifest path was '%v'", result)
+	}
+
+	config.AppTarget = "Runner"
+	config.FlavorName = ""
+	if result := getPlatformSpecificIosProjPath(config); result != "/app/ios" {
+		t.Errorf("Incorrect result, iOS project path was '%v'", result)
+	}
+	if result := config.getIosConfigModelPath(); result != "/app/ios/Runner/Configuration" {
+		t.Errorf("Incorrect result, iOS config model path was '%v'", result)
+	}
+}
+
+func TestParseReactNativeConfig(t *testing.T) {
+	appDir := t.TempDir()
+	config := &Config{AppDir: appDir}
+	if err := ParseReactNativeConfig(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for a missing app.json and package.json")
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "package.json"), []byte(`{"name": "example-package"}`), 0644)
+	if err := ParseReactNativeConfig(config); err != nil || config.ReactNative.Name != "example-package" {
+		t.Errorf("Incorrect result, expected the name in the package.json but was '%v' (%v)", config.ReactNative.Name, err)
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "app.json"), []byte(`{"name": "ExampleApp", "displayName": "Example App"}`), 0644)
+	if err := ParseReactNativeConfig(config); err != nil || config.ReactNative.Name != "ExampleApp" {
+		t.Errorf("Incorrect result, expected the name in the app.json but was '%v' (%v)", config.ReactNative.Name, err)
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "app.json"), []byte(`{"expo": {"name": "ExpoApp"}}`), 0644)
+	if err := ParseReactNativeConfig(config); err != nil || config.ReactNative.Name != "ExpoApp" {
+		t.Errorf("Incorrect result, expected the Expo name in the app.json but was '%v' (%v)", config.ReactNative.Name, err)
+	}
+}
+
+func TestParseNativeScriptConfig(t *testing.T) {
+	appDir := t.TempDir()
+	config := &Config{AppDir: appDir}
+	if err := ParseNativeScriptConfig(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for a missing package.json")
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "package.json"), []byte(`{"nativescript": {"id": "com.onegini.example"`), 0644)
+	if err := ParseNativeScriptConfig(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for an invalid package.json")
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "package.json"), []byte(`{"nativescript": {"id": "com.onegini.example"}}`), 0644)
+	if err := ParseNativeScriptConfig(config); err != nil || config.NativeScript.NS.ID != "com.onegini.example" {
+		t.Errorf("Incorrect result, expected the id in the package.json but was '%v' (%v)", config.NativeScript.NS.ID, err)
+	}
+}
+
+func TestParseCapacitorConfig(t *testing.T) {
+	appDir := t.TempDir()
+	config := &Config{AppDir: appDir}
+	if err := ParseCapacitorConfig(config); err == nil {
+		t.Errorf("Incorrect result, expected an error for a missing Capacitor config")
+	}
+
+	tsConfig := "import { CapacitorConfig } from '@capacitor/cli';\n\nconst config: CapacitorConfig = {\n  appId: 'com.example.app',\n  appName: \"Example App\",\n" +
+		"  cordova: {\n    preferences: {\n      OneginiWebView: 'disabled',\n    },\n  },\n};\n\nexport default config;\n"
+	_ = os.WriteFile(filepath.Join(appDir, "capacitor.config.ts"), []byte(tsConfig), 0644)
+	if err := ParseCapacitorConfig(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Capacitor.AppId != "com.example.app" || config.Capacitor.AppName != "Example App" || !shouldRemoveIntentFilter(config) {
+		t.Errorf("Incorrect result, unexpected Capacitor config from capacitor.config.ts: %+v", config.Capacitor)
+	}
+
+	_ = os.WriteFile(filepath.Join(appDir, "capacitor.config.json"), []byte(`{"appId": "com.example.json", "appName": "Example"}`), 0644)
+	if err := ParseCapacitorConfig(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Capacitor.AppId != "com.example.json" || shouldRemoveIntentFilter(config) {
+		t.Errorf("Incorrect result, unexpected Capacitor config from capacitor.config.json: %+v", config.Capacitor)
+	}
+}
+
+func TestCapacitorPaths(t *testing.T) {
+	config := &Config{AppDir: "/app", ConfigureForCapacitor: true, AppTarget: "App"}
+
+	if result := getPlatformSpecificIosProjPath(config); result != "/app/ios/App" {
+		t.Errorf("Incorrect result, iOS project path was '%v'", result)
+	}
+	if result := config.getIosConfigModelPath(); result != "/app/ios/App/App/Configuration" {
+		t.Errorf("Incorrect result, iOS config model path was '%v'", result)
+	}
+
+	config.AppTarget = "app"
+	if result := config.getAndroidManifestPath(); result != "/app/android/app/src/main/AndroidManifest.xml" {
+		t.Errorf("Incorrect result, Android manifest path was '%v'", result)
+	}
+}
@@ -0,0 +1,175 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+type diffOperation struct {
+	kind byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns the difference between both versions of a file in the unified diff format.
+func unifiedDiff(filePath string, before []byte, after []byte, kind string) string {
+	oldName, newName := filePath, filePath
+	if kind == ChangeCreate {
+		oldName = "/dev/null"
+	} else if kind == ChangeDelete {
+		newName = "/dev/null"
+	}
+
+	operations := diffLines(splitLines(string(before)), splitLines(string(after)))
+
+	var diff strings.Builder
+	diff.WriteString(fmt.Sprintf("--- %v\n+++ %v\n", oldName, newName))
+
+	for start := 0; start < len(operations); {
+		if operations[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// a hunk ends once the next change is further away than the context lines on both sides of it
+		end := start + 1
+		for next := end; next < len(operations) && next-end <= 2*diffContextLines; next++ {
+			if operations[next].kind != ' ' {
+				end = next + 1
+			}
+		}
+		hunkStart := max(start-diffContextLines, 0)
+		hunkEnd := min(end+diffContextLines, len(operations))
+
+		oldStart, newStart := lineNumbers(operations[:hunkStart])
+		oldCount, newCount := lineNumbers(operations[hunkStart:hunkEnd])
+		diff.WriteString(fmt.Sprintf("@@ -%v +%v @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount)))
+		for _, operation := range operations[hunkStart:hunkEnd] {
+			diff.WriteString(string(operation.kind) + operation.line + "\n")
+		}
+
+		start = hunkEnd
+	}
+
+	return diff.String()
+}
+
+func splitLines(contents string) []string {
+	if contents == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(contents, "\n"), "\n")
+}
+
+func lineNumbers(operations []diffOperation) (oldLines int, newLines int) {
+	for _, operation := range operations {
+		if operation.kind != '+' {
+			oldLines++
+		}
+		if operation.kind != '-' {
+			newLines++
+		}
+	}
+	return
+}
+
+func hunkRange(start int, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%v,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%v", start+1)
+	}
+	return fmt.Sprintf("%v,%v", start+1, count)
+}
+
+// diffLines computes the shortest edit script between both sets of lines using the Myers algorithm.
+func diffLines(before []string, after []string) []diffOperation {
+	n, m := len(before), len(after)
+	offset := n + m
+	frontier := make([]int, 2*offset+2)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		snapshot := make([]int, len(frontier))
+		copy(snapshot, frontier)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && frontier[offset+k-1] < frontier[offset+k+1]) {
+				x = frontier[offset+k+1]
+			} else {
+				x = frontier[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && before[x] == after[y] {
+				x++
+				y++
+			}
+			frontier[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrackDiff(trace, before, after, offset, d)
+			}
+		}
+	}
+
+	return nil
+}
+
+func backtrackDiff(trace [][]int, before []string, after []string, offset int, d int) []diffOperation {
+	var operations []diffOperation
+	x, y := len(before), len(after)
+
+	for ; d > 0; d-- {
+		frontier := trace[d]
+		k := x - y
+		var previousK int
+		if k == -d || (k != d && frontier[offset+k-1] < frontier[offset+k+1]) {
+			previousK = k + 1
+		} else {
+			previousK = k - 1
+		}
+		previousX := frontier[offset+previousK]
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			x--
+			y--
+			operations = append(operations, diffOperation{' ', before[x]})
+		}
+		if x == previousX {
+			y--
+			operations = append(operations, diffOperation{'+', after[y]})
+		} else {
+			x--
+			operations = append(operations, diffOperation{'-', before[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		operations = append(operations, diffOperation{' ', before[x]})
+	}
+
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+	return operations
+}
@@ -0,0 +1,66 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the util package. Errors that relate to a specific file are wrapped, use errors.Is to check for them.
+var (
+	ErrMissingConfigZip          = errors.New("no Token Server configuration provided")
+	ErrInvalidConfigZip          = errors.New("the provided configuration zip does not contain the required information")
+	ErrMissingResourceGateway    = errors.New("no resource gateway URI is specified in the configuration zip")
+	ErrMissingCertificates       = errors.New("the configuration zip does not contain any certificates")
+	ErrInvalidCertificate        = errors.New("the certificate does not have the correct format")
+	ErrExpiredCertificate        = errors.New("the certificate has expired")
+	ErrInvalidRedirectUrl        = errors.New("cannot parse the redirect URL")
+	ErrInvalidOption             = errors.New("invalid Token Server configuration option")
+	ErrXcodeProjectNotFound      = errors.New("could not find an Xcode project directory (.xcodeproj)")
+	ErrActivityNotFound          = errors.New("could not find the activity that handles the redirect URL in the Android Manifest")
+	ErrInfoPlistNotFound         = errors.New("could not find the Info.plist of the app target")
+	ErrMultipleXcodeProjects     = errors.New("found multiple Xcode project directories (.xcodeproj) and none of them is referenced by a workspace or named after the target")
+	ErrInvalidSigningCertificate = errors.New("cannot read the app signing certificate")
+	ErrInvalidBuildVariant       = errors.New("the build variant does not match the Gradle file of the module")
+)
+
+// CertificateError reports a problem with one of the certificate files in the configuration zip.
+type CertificateError struct {
+	Name string
+	Err  error
+}
+
+func (err *CertificateError) Error() string {
+	return fmt.Sprintf("certificate '%v': %v", err.Name, err.Err)
+}
+
+func (err *CertificateError) Unwrap() error {
+	return err.Err
+}
+
+// OptionError reports an invalid option in the config.json of the configuration zip, it always wraps ErrInvalidOption.
+type OptionError struct {
+	Field   string
+	Message string
+}
+
+func (err *OptionError) Error() string {
+	return fmt.Sprintf("option '%v': %v", err.Field, err.Message)
+}
+
+func (err *OptionError) Unwrap() error {
+	return ErrInvalidOption
+}
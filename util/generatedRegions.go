@@ -0,0 +1,148 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Generated code in a config model is delimited by marker comments:
+//
+//	// sdk-configurator: begin generated <name>
+//	...
+//	// sdk-configurator: end generated <name>
+//
+// When a config model is regenerated only the regions between the markers are replaced, everything outside of them is kept as is.
+var generatedRegionMarkerRegex = regexp.MustCompile(`^\s*// sdk-configurator: (begin|end) generated (\w+)\s*$`)
+
+type generatedRegion struct {
+	name  string
+	start int // line of the begin marker
+	end   int // line of the end marker
+}
+
+// mergeGeneratedRegions replaces the generated regions in the existing file with the ones from the newly generated file. It returns
+// false when the existing file does not contain any markers, e.g. because it was generated by an older version of the configurator. It is
+// an error when the existing file does contain markers but the newly generated one does not, since none of its regions would be updated.
+func mergeGeneratedRegions(existing []byte, generated []byte) (merged []byte, ok bool, err error) {
+	existingLines := strings.Split(string(existing), "\n")
+	generatedLines := strings.Split(string(generated), "\n")
+
+	existingRegions, err := findGeneratedRegions(existingLines)
+	if err != nil {
+		return nil, false, fmt.Errorf("the existing file contains invalid markers: %w", err)
+	}
+	if len(existingRegions) == 0 {
+		return nil, false, nil
+	}
+	generatedRegions, err := findGeneratedRegions(generatedLines)
+	if err != nil {
+		return nil, false, fmt.Errorf("the config model template contains invalid markers: %w", err)
+	}
+	if len(generatedRegions) == 0 {
+		return nil, false, errors.New("the config model template does not contain any generated region markers, add them to the template or " +
+			"remove the file to generate it from scratch")
+	}
+
+	existingRegionsByName := make(map[string]generatedRegion)
+	for _, region := range existingRegions {
+		existingRegionsByName[region.name] = region
+	}
+	replacements := make(map[string][]string)
+	for _, region := range generatedRegions {
+		if _, found := existingRegionsByName[region.name]; !found {
+			return nil, false, fmt.Errorf("the existing file does not contain the generated region '%v', remove the file to generate it from scratch", region.name)
+		}
+		replacements[region.name] = generatedLines[region.start : region.end+1]
+	}
+
+	var mergedLines []string
+	previousEnd := 0
+	for _, region := range existingRegions {
+		mergedLines = append(mergedLines, existingLines[previousEnd:region.start]...)
+		if replacement, found := replacements[region.name]; found {
+			mergedLines = append(mergedLines, reindentLines(replacement, existingLines[region.start])...)
+		} else {
+			// regions that are no longer generated are left alone
+			mergedLines = append(mergedLines, existingLines[region.start:region.end+1]...)
+		}
+		previousEnd = region.end + 1
+	}
+	mergedLines = append(mergedLines, existingLines[previousEnd:]...)
+
+	return []byte(strings.Join(mergedLines, "\n")), true, nil
+}
+
+func findGeneratedRegions(lines []string) ([]generatedRegion, error) {
+	var regions []generatedRegion
+	var current *generatedRegion
+	names := make(map[string]bool)
+
+	for i, line := range lines {
+		match := generatedRegionMarkerRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		kind, name := match[1], match[2]
+
+		if kind == "begin" {
+			if current != nil {
+				return nil, fmt.Errorf("region '%v' starts on line %v before region '%v' ends", name, i+1, current.name)
+			}
+			if names[name] {
+				return nil, fmt.Errorf("region '%v' occurs more than once", name)
+			}
+			names[name] = true
+			current = &generatedRegion{name: name, start: i}
+		} else {
+			if current == nil || current.name != name {
+				return nil, fmt.Errorf("region '%v' ends on line %v without being started", name, i+1)
+			}
+			current.end = i
+			regions = append(regions, *current)
+			current = nil
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("region '%v' is not ended", current.name)
+	}
+	return regions, nil
+}
+
+// reindentLines changes the indentation of the generated lines to the one of the begin marker in the existing file, in case it was reformatted.
+func reindentLines(lines []string, existingMarker string) []string {
+	generatedIndent := leadingWhitespace(lines[0])
+	existingIndent := leadingWhitespace(existingMarker)
+	if generatedIndent == existingIndent {
+		return lines
+	}
+
+	reindented := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, generatedIndent) {
+			line = existingIndent + strings.TrimPrefix(line, generatedIndent)
+		}
+		reindented[i] = line
+	}
+	return reindented
+}
+
+func leadingWhitespace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
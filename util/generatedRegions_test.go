@@ -0,0 +1,148 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const generatedModel = `@implementation OneginiConfigModel
+// sdk-configurator: begin generated header
+// version 2
+// sdk-configurator: end generated header
+
++ (NSDictionary *)configuration
+{
+    return @{
+             // sdk-configurator: begin generated configuration
+             @"ONGAppVersion" : @"2.0.0",
+             // sdk-configurator: end generated configuration
+             };
+}
+@end
+`
+
+func TestMergeGeneratedRegions(t *testing.T) {
+	existing := `@implementation OneginiConfigModel
+// sdk-configurator: begin generated header
+// version 1
+// sdk-configurator: end generated header
+
++ (NSDictionary *)configuration
+{
+    return @{
+        // sdk-configurator: begin generated configuration
+        @"ONGAppVersion" : @"1.0.0",
+        // sdk-configurator: end generated configuration
+        @"MyCustomKey" : @"custom",
+    };
+}
+
++ (NSString *)customMethod
+{
+    return @"custom";
+}
+@end
+`
+	expected := `@implementation OneginiConfigModel
+// sdk-configurator: begin generated header
+// version 2
+// sdk-configurator: end generated header
+
++ (NSDictionary *)configuration
+{
+    return @{
+        // sdk-configurator: begin generated configuration
+        @"ONGAppVersion" : @"2.0.0",
+        // sdk-configurator: end generated configuration
+        @"MyCustomKey" : @"custom",
+    };
+}
+
++ (NSString *)customMethod
+{
+    return @"custom";
+}
+@end
+`
+
+	merged, ok, err := mergeGeneratedRegions([]byte(existing), []byte(generatedModel))
+	if err != nil || !ok {
+		t.Fatalf("Unexpected result: %v, %v", ok, err)
+	}
+	if string(merged) != expected {
+		t.Errorf("Incorrect result, only the generated regions should be replaced:\n%v", string(merged))
+	}
+}
+
+func TestMergeGeneratedRegionsWithoutMarkers(t *testing.T) {
+	if _, ok, err := mergeGeneratedRegions([]byte("@implementation OneginiConfigModel\n@end\n"), []byte(generatedModel)); ok || err != nil {
+		t.Errorf("Incorrect result, a file without markers cannot be merged: %v, %v", ok, err)
+	}
+
+	invalidFiles := []string{
+		"// sdk-configurator: begin generated header\n",
+		"// sdk-configurator: end generated header\n",
+		"// sdk-configurator: begin generated header\n// sdk-configurator: begin generated configuration\n",
+		// the configuration region is missing
+		"// sdk-configurator: begin generated header\n// sdk-configurator: end generated header\n",
+	}
+	for _, invalidFile := range invalidFiles {
+		if _, _, err := mergeGeneratedRegions([]byte(invalidFile), []byte(generatedModel)); err == nil {
+			t.Errorf("Incorrect result, expected an error for:\n%v", invalidFile)
+		}
+	}
+}
+
+func TestMergeGeneratedRegionsWithoutMarkersInTemplate(t *testing.T) {
+	generatedWithoutMarkers := "@implementation OneginiConfigModel\n// version 2\n@end\n"
+	if _, ok, err := mergeGeneratedRegions([]byte(generatedModel), []byte(generatedWithoutMarkers)); ok || err == nil {
+		t.Errorf("Incorrect result, expected an error when the template does not contain any markers: %v, %v", ok, err)
+	}
+}
+
+// should keep the code that was added to an existing config model when it is generated again
+func TestWriteIOSConfigModelPreservesUserEdits(t *testing.T) {
+	appDir, _ := writeExampleXcodeProj(t)
+	config := &Config{
+		Options:   validTestOptions(),
+		Certs:     map[string]string{},
+		AppDir:    appDir,
+		AppTarget: "Example",
+	}
+	modelPath := config.getIosConfigModelPathMFile()
+	_ = os.MkdirAll(filepath.Dir(modelPath), 0755)
+
+	if err := WriteIOSConfigModel(config, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	model, _ := config.readFile(modelPath)
+	userEdit := "\n+ (NSString *)customMethod\n{\n    return @\"custom\";\n}\n"
+	_ = os.WriteFile(modelPath, []byte(strings.Replace(string(model), "\n@end", userEdit+"\n@end", 1)), 0644)
+
+	config = &Config{Options: config.Options, Certs: config.Certs, AppDir: appDir, AppTarget: "Example"}
+	config.Options.AppVersion = "2.0.0"
+	if err := WriteIOSConfigModel(config, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	model, _ = config.readFile(modelPath)
+	if !strings.Contains(string(model), userEdit) || !strings.Contains(string(model), `@"ONGAppVersion" : @"2.0.0"`) {
+		t.Errorf("Incorrect result, the user edit should be preserved and the configuration updated:\n%v", string(model))
+	}
+}
@@ -0,0 +1,421 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	gradleBuildFileName    = "build.gradle"
+	gradleKtsBuildFileName = "build.gradle.kts"
+	gradlePropertiesName   = "gradle.properties"
+
+	// the maximum number of variables that are followed to resolve a value
+	gradleMaxResolveDepth = 8
+)
+
+var (
+	gradleStringRegexp            = regexp.MustCompile(`^(?:"([^"]*)"|'([^']*)')$`)
+	gradleInterpolationRegexp     = regexp.MustCompile(`\$\{([^}]+)\}|\$([A-Za-z_]\w*)`)
+	gradlePropertyCallRegexp      = regexp.MustCompile(`^(?:(?:project|rootProject)\.)?(?:property|findProperty)\(\s*["']([^"']+)["']\s*\)$`)
+	gradleProvidersPropertyRegexp = regexp.MustCompile(`^providers\.gradleProperty\(\s*["']([^"']+)["']\s*\)$`)
+	gradleExtraPropertyRegexp     = regexp.MustCompile(`^(?:(?:project|rootProject)\.)?(?:extra|ext)\[\s*["']([^"']+)["']\s*\]$`)
+	gradleVersionCatalogRegexp    = regexp.MustCompile(`^libs\.versions\.([\w.]+)$`)
+	gradleIdentifierRegexp        = regexp.MustCompile(`^(?:(?:project|rootProject)\.)?(?:ext\.)?([A-Za-z_]\w*)$`)
+	tomlVersionRegexp             = regexp.MustCompile(`^([\w.-]+)\s*=\s*"([^"]*)"`)
+	gradleQuotedPathRegexp        = regexp.MustCompile(`["']([^"']+)["']`)
+	gradleProjectDirRegexp        = regexp.MustCompile(`^\$\{?(?:project\.)?projectDir\}?/`)
+)
+
+// gradleBuildFile is the build file of a Gradle module, written in either the Groovy or the Kotlin DSL. Values can be set through variables
+// in the build file, properties in gradle.properties and versions in the version catalog (gradle/libs.versions.toml) of the project.
+type gradleBuildFile struct {
+	path       string
+	content    string
+	properties map[string]string
+	versions   map[string]string
+}
+
+// loadAndroidBuildFile loads the build.gradle or build.gradle.kts of the app module together with the properties and the version catalog of
+// the Gradle project.
+func (config *Config) loadAndroidBuildFile() (*gradleBuildFile, error) {
+	moduleDir := config.getAndroidModulePath()
+	buildFilePath := path.Join(moduleDir, gradleBuildFileName)
+	if !config.fileExists(buildFilePath) && config.fileExists(path.Join(moduleDir, gradleKtsBuildFileName)) {
+		buildFilePath = path.Join(moduleDir, gradleKtsBuildFileName)
+	}
+	content, err := config.readFile(buildFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	buildFile := &gradleBuildFile{
+		path:       buildFilePath,
+		content:    removeGradleComments(string(content)),
+		properties: map[string]string{},
+		versions:   map[string]string{},
+	}
+	projectDir := findGradleProjectDir(config, moduleDir)
+	// the properties of the module override the properties of the project
+	for _, propertiesPath := range []string{path.Join(projectDir, gradlePropertiesName), path.Join(moduleDir, gradlePropertiesName)} {
+		if properties, err := config.readFile(propertiesPath); err == nil {
+			parseGradleProperties(properties, buildFile.properties)
+		}
+	}
+	if versionCatalog, err := config.readFile(path.Join(projectDir, "gradle", "libs.versions.toml")); err == nil {
+		parseVersionCatalogVersions(versionCatalog, buildFile.versions)
+	}
+	return buildFile, nil
+}
+
+// findGradleProjectDir returns the root directory of the Gradle project, which contains the settings file. It defaults to the parent directory
+// of the module.
+func findGradleProjectDir(config *Config, moduleDir string) string {
+	for dir := path.Dir(moduleDir); dir != path.Dir(dir); dir = path.Dir(dir) {
+		if config.fileExists(path.Join(dir, "settings.gradle")) || config.fileExists(path.Join(dir, "settings.gradle.kts")) {
+			return dir
+		}
+		if dir == config.AppDir {
+			break
+		}
+	}
+	return path.Dir(moduleDir)
+}
+
+// namespace returns the namespace of the android block, which is the package of the generated R class and of the config model.
+func (buildFile *gradleBuildFile) namespace() string {
+	androidBlock, ok := gradleBlock(buildFile.content, "android")
+	if !ok {
+		androidBlock = buildFile.content
+	}
+	return buildFile.resolveSetting(androidBlock, "namespace")
+}
+
+// applicationId returns the application ID of the variant: the application ID of the flavor with the highest priority that overrides it, or of
+// the default config, followed by the applicationIdSuffix of the default config, the flavors from the lowest priority to the highest and the
+// build type. The flavors are given in the order of their flavor dimensions. The application ID is empty when the build file does not set one.
+func (buildFile *gradleBuildFile) applicationId(flavorNames []string, buildTypeName string) string {
+	androidBlock, _ := gradleBlock(buildFile.content, "android")
+	defaultConfig, _ := gradleBlock(androidBlock, "defaultConfig")
+	productFlavors, _ := gradleBlock(androidBlock, "productFlavors")
+	var flavors []string
+	for _, flavorName := range flavorNames {
+		flavor, _ := gradleBlock(productFlavors, flavorName)
+		flavors = append(flavors, flavor)
+	}
+	buildType := ""
+	if len(buildTypeName) > 0 {
+		buildTypes, _ := gradleBlock(androidBlock, "buildTypes")
+		buildType, _ = gradleBlock(buildTypes, buildTypeName)
+	}
+
+	applicationId := ""
+	for _, flavor := range flavors {
+		if applicationId = buildFile.resolveSetting(flavor, "applicationId"); len(applicationId) > 0 {
+			break
+		}
+	}
+	if len(applicationId) == 0 {
+		applicationId = buildFile.resolveSetting(defaultConfig, "applicationId")
+	}
+	if len(applicationId) == 0 {
+		return ""
+	}
+
+	applicationId += buildFile.resolveSetting(defaultConfig, "applicationIdSuffix")
+	for i := len(flavors) - 1; i >= 0; i-- {
+		applicationId += buildFile.resolveSetting(flavors[i], "applicationIdSuffix")
+	}
+	return applicationId + buildFile.resolveSetting(buildType, "applicationIdSuffix")
+}
+
+// kotlinSourceDir returns the directory with Kotlin sources that the build file adds to the source set, e.g.
+// `java.srcDirs += 'src/main/kotlin'` or `sourceSets["main"].kotlin.srcDir("src/main/kotlin")`. The directory is relative to the module, it is
+// empty when the build file does not configure one.
+func (buildFile *gradleBuildFile) kotlinSourceDir(sourceSetName string) string {
+	androidBlock, _ := gradleBlock(buildFile.content, "android")
+	sourceSets, _ := gradleBlock(androidBlock, "sourceSets")
+	sourceSet, _ := gradleBlock(sourceSets, sourceSetName)
+
+	quotedName := regexp.QuoteMeta(sourceSetName)
+	namedSourceSet := `(?:getByName|named)\(\s*["']` + quotedName + `["']\s*\)`
+	sourceDirs := `(java|kotlin)\.(?:srcDirs?|setSrcDirs)\b(.*)$`
+	declarations := []struct {
+		block  string
+		prefix string
+	}{
+		{removeNestedGradleBlocks(androidBlock), `sourceSets(?:\.` + quotedName + `|\[\s*["']` + quotedName + `["']\s*\]|\.` + namedSourceSet + `)\.`},
+		{removeNestedGradleBlocks(sourceSets), `(?:` + quotedName + `|` + namedSourceSet + `)\.`},
+		{removeNestedGradleBlocks(sourceSet), ``},
+	}
+
+	// a directory that is added for Kotlin sources is preferred over other directories that are added to the Java sources
+	kotlinDir := ""
+	for _, declaration := range declarations {
+		declarationRegexp := regexp.MustCompile(`(?m)^\s*` + declaration.prefix + sourceDirs)
+		for _, matches := range declarationRegexp.FindAllStringSubmatch(declaration.block, -1) {
+			for _, quotedPath := range gradleQuotedPathRegexp.FindAllStringSubmatch(matches[2], -1) {
+				dir := gradleProjectDirRegexp.ReplaceAllString(quotedPath[1], "")
+				if path.Base(dir) == "kotlin" {
+					return dir
+				}
+				if matches[1] == "kotlin" && len(kotlinDir) == 0 {
+					kotlinDir = dir
+				}
+			}
+		}
+	}
+	return kotlinDir
+}
+
+// resolveSetting returns the value of a setting in the block, e.g. `namespace 'com.example'` or `namespace = "com.example"`. Settings in nested
+// blocks are ignored.
+func (buildFile *gradleBuildFile) resolveSetting(block string, name string) string {
+	settingRegexp := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(name) + `(?:\s*=\s*(.+?)|\s*\((.+)\)|\s+(.+?))\s*;?\s*$`)
+	matches := settingRegexp.FindStringSubmatch(removeNestedGradleBlocks(block))
+	if matches == nil {
+		return ""
+	}
+	if value, ok := buildFile.resolveValue(matches[1]+matches[2]+matches[3], 0); ok {
+		return value
+	}
+	return ""
+}
+
+// resolveValue evaluates the expressions that are commonly used to set a value: string literals, string templates, variables, project
+// properties and versions from the version catalog.
+func (buildFile *gradleBuildFile) resolveValue(expression string, depth int) (string, bool) {
+	if depth > gradleMaxResolveDepth {
+		return "", false
+	}
+	// conversions and provider calls don't change the value
+	for trimmed := ""; trimmed != expression; {
+		trimmed = expression
+		for _, suffix := range []string{" as String", "!!", ".toString()", ".get()"} {
+			expression = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(expression), suffix))
+		}
+	}
+
+	if matches := gradleStringRegexp.FindStringSubmatch(expression); matches != nil {
+		resolved := true
+		value := gradleInterpolationRegexp.ReplaceAllStringFunc(matches[1]+matches[2], func(interpolation string) string {
+			variable := gradleInterpolationRegexp.FindStringSubmatch(interpolation)
+			value, ok := buildFile.resolveValue(variable[1]+variable[2], depth+1)
+			resolved = resolved && ok
+			return value
+		})
+		return value, resolved
+	}
+	if matches := gradleVersionCatalogRegexp.FindStringSubmatch(expression); matches != nil {
+		value, ok := buildFile.versions[normalizeVersionCatalogAlias(matches[1])]
+		return value, ok
+	}
+	for _, propertyRegexp := range []*regexp.Regexp{gradlePropertyCallRegexp, gradleProvidersPropertyRegexp, gradleExtraPropertyRegexp} {
+		if matches := propertyRegexp.FindStringSubmatch(expression); matches != nil {
+			if value, ok := buildFile.properties[matches[1]]; ok {
+				return value, true
+			}
+			return buildFile.resolveVariable(matches[1], depth)
+		}
+	}
+	if matches := gradleIdentifierRegexp.FindStringSubmatch(expression); matches != nil {
+		if value, ok := buildFile.resolveVariable(matches[1], depth); ok {
+			return value, true
+		}
+		value, ok := buildFile.properties[matches[1]]
+		return value, ok
+	}
+	return "", false
+}
+
+// resolveVariable returns the value of a variable that is declared in the build file, e.g. `val appNamespace = "com.example"` or
+// `ext.appNamespace = "com.example"`.
+func (buildFile *gradleBuildFile) resolveVariable(name string, depth int) (string, bool) {
+	variableRegexp := regexp.MustCompile(`(?m)^\s*(?:(?:val|var|def|String)\s+|(?:project\.)?ext\.|extra\[\s*["'])` + regexp.QuoteMeta(name) +
+		`(?:["']\s*\])?(?:\s*:\s*String)?\s*=\s*(.+?)\s*;?\s*$`)
+	matches := variableRegexp.FindStringSubmatch(buildFile.content)
+	if matches == nil {
+		return "", false
+	}
+	return buildFile.resolveValue(matches[1], depth+1)
+}
+
+// gradleBlock returns the body of the first block with the name, e.g. `android { ... }`. Blocks of the Kotlin DSL that are created by name,
+// e.g. `create("production") { ... }`, are found as well.
+func gradleBlock(content string, name string) (string, bool) {
+	quotedName := regexp.QuoteMeta(name)
+	headerRegexp := regexp.MustCompile(`(?m)^\s*(?:` + quotedName + `|(?:create|register|getByName|maybeCreate|named)\(\s*["']` + quotedName + `["']\s*\)|["']` +
+		quotedName + `["'])\s*\{`)
+	location := headerRegexp.FindStringIndex(content)
+	if location == nil {
+		return "", false
+	}
+
+	start := location[1]
+	depth := 1
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '"', '\'':
+			i = skipGradleString(content, i)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[start:i], true
+			}
+		}
+	}
+	return content[start:], true
+}
+
+// removeNestedGradleBlocks removes the bodies of the blocks in the content, so that only the settings of the content itself remain.
+func removeNestedGradleBlocks(content string) string {
+	var result strings.Builder
+	depth := 0
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"', '\'':
+			end := skipGradleString(content, i)
+			if depth == 0 {
+				result.WriteString(content[i : end+1])
+			}
+			i = end
+			continue
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+				continue
+			}
+		}
+		if depth == 0 {
+			result.WriteByte(content[i])
+		}
+	}
+	return result.String()
+}
+
+// skipGradleString returns the index of the closing quote of the string that starts at the index.
+func skipGradleString(content string, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		case '\n':
+			return i - 1
+		}
+	}
+	return len(content) - 1
+}
+
+// removeGradleComments removes the line and block comments from a build file, comment markers in strings (e.g. in URLs) are kept.
+func removeGradleComments(content string) string {
+	var result strings.Builder
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '"' || content[i] == '\'':
+			end := skipGradleString(content, i)
+			result.WriteString(content[i : end+1])
+			i = end
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				return result.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				return result.String()
+			}
+			// keep the line breaks so that settings stay on their own line
+			result.WriteString(strings.Repeat("\n", strings.Count(content[i:i+2+end], "\n")))
+			i += end + 3
+		default:
+			result.WriteByte(content[i])
+		}
+	}
+	return result.String()
+}
+
+// parseGradleProperties reads the properties in the Java properties format, line continuations are not supported.
+func parseGradleProperties(contents []byte, properties map[string]string) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		separator := strings.IndexAny(line, "=:")
+		if separator < 0 {
+			continue
+		}
+		properties[strings.TrimSpace(line[:separator])] = strings.TrimSpace(line[separator+1:])
+	}
+}
+
+// parseVersionCatalogVersions reads the [versions] table of a version catalog. The keys are normalized the same way Gradle normalizes them for
+// the generated accessors, e.g. the key app-namespace is used as libs.versions.app.namespace.
+func parseVersionCatalogVersions(contents []byte, versions map[string]string) {
+	inVersions := false
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inVersions = line == "[versions]"
+			continue
+		}
+		if matches := tomlVersionRegexp.FindStringSubmatch(line); inVersions && matches != nil {
+			versions[normalizeVersionCatalogAlias(matches[1])] = matches[2]
+		}
+	}
+}
+
+func normalizeVersionCatalogAlias(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
+
+// getAndroidApplicationId returns the application ID of the app, which identifies it on the device and in the Play Store, including the suffixes
+// of the flavor and the build type. It falls back to the package name of the config model when the build file does not set an application ID.
+func (config *Config) getAndroidApplicationId() string {
+	if config.ConfigureForCordova || config.ConfigureForNativeScript {
+		return getPackageIdentifierFromConfig(config)
+	}
+
+	buildFile, err := config.loadAndroidBuildFile()
+	if err == nil {
+		if applicationId := buildFile.applicationId(config.getAndroidVariantFlavors(buildFile), config.BuildType); len(applicationId) > 0 {
+			return applicationId
+		}
+	} else if !os.IsNotExist(err) {
+		config.AddWarning(fmt.Sprintf("Could not read the Gradle file: %v", err))
+	}
+	if config.ConfigureForCapacitor && len(config.Capacitor.AppId) > 0 {
+		return config.Capacitor.AppId
+	}
+	return getPackageIdentifierFromConfig(config)
+}
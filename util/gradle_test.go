@@ -0,0 +1,260 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+const groovyBuildFile = `plugins {
+    id 'com.android.application'
+}
+
+// namespace 'com.example.commented'
+android {
+    namespace appNamespace
+    compileSdk 34
+
+    defaultConfig {
+        applicationId "${appNamespace}.app" /* the package in the store */
+        minSdk 24
+    }
+
+    flavorDimensions "environment"
+    productFlavors {
+        development {
+            dimension "environment"
+            applicationIdSuffix ".dev"
+        }
+        production {
+            dimension "environment"
+            applicationId 'com.example.store'
+        }
+    }
+}
+
+dependencies {
+    implementation 'com.onegini.mobile.sdk.android:onegini-sdk:12.0.0@aar'
+}
+`
+
+const ktsBuildFile = `plugins {
+    alias(libs.plugins.android.application)
+}
+
+val baseApplicationId = providers.gradleProperty("baseApplicationId").get()
+
+android {
+    namespace = libs.versions.app.namespace.get()
+
+    defaultConfig {
+        applicationId = baseApplicationId
+        applicationIdSuffix = ".app"
+    }
+
+    productFlavors {
+        create("acceptance") {
+            applicationIdSuffix = ".acc"
+        }
+        register("production") {
+            applicationId = project.property("storeApplicationId") as String
+        }
+    }
+}
+`
+
+func writeGradleProject(t *testing.T, files map[string]string) *Config {
+	appDir := t.TempDir()
+	for name, contents := range files {
+		filePath := filepath.Join(appDir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(filePath), 0755)
+		_ = os.WriteFile(filePath, []byte(contents), 0644)
+	}
+	return &Config{AppDir: appDir, AppTarget: "app"}
+}
+
+func TestGradleGroovyBuildFile(t *testing.T) {
+	config := writeGradleProject(t, map[string]string{
+		"settings.gradle":   "include ':app'",
+		"gradle.properties": "org.gradle.jvmargs=-Xmx2048m\nappNamespace=com.example\n",
+		"app/build.gradle":  groovyBuildFile,
+	})
+
+	if namespace := config.getAndroidNamespacePath(); namespace != "com.example" {
+		t.Errorf("Incorrect namespace, expected com.example but was %v", namespace)
+	}
+
+	testCases := []struct {
+		flavorName    string
+		applicationId string
+	}{
+		{"", "com.example.app"},
+		{"development", "com.example.app.dev"},
+		{"production", "com.example.store"},
+	}
+	for _, testCase := range testCases {
+		SetFlavorName(testCase.flavorName, config)
+		if applicationId := config.getAndroidApplicationId(); applicationId != testCase.applicationId {
+			t.Errorf("Incorrect application ID for flavor '%v', expected %v but was %v", testCase.flavorName, testCase.applicationId, applicationId)
+		}
+	}
+}
+
+func TestGradleKtsBuildFile(t *testing.T) {
+	config := writeGradleProject(t, map[string]string{
+		"settings.gradle.kts":              `include(":app")`,
+		"gradle.properties":                "baseApplicationId = com.example\nstoreApplicationId: com.example.store\n",
+		"gradle/libs.versions.toml":        "[versions]\nagp = \"8.5.0\"\napp-namespace = \"com.example.kts\"\n\n[plugins]\nandroid-application = { id = \"com.android.application\", version.ref = \"agp\" }\n",
+		"app/build.gradle.kts":             ktsBuildFile,
+		"app/src/main/AndroidManifest.xml": `<manifest xmlns:android="http://schemas.android.com/apk/res/android" />`,
+	})
+
+	if namespace := config.getAndroidNamespacePath(); namespace != "com.example.kts" {
+		t.Errorf("Incorrect namespace, expected com.example.kts but was %v", namespace)
+	}
+	if modelPath := config.getAndroidConfigModelKotlinPath(); modelPath != filepath.ToSlash(filepath.Join(config.AppDir, "app/src/main/java/com/example/kts/OneginiConfigModel.kt")) {
+		t.Errorf("Incorrect config model path: %v", modelPath)
+	}
+
+	testCases := []struct {
+		flavorName    string
+		applicationId string
+	}{
+		{"", "com.example.app"},
+		{"acceptance", "com.example.app.acc"},
+		{"production", "com.example.store.app"},
+	}
+	for _, testCase := range testCases {
+		SetFlavorName(testCase.flavorName, config)
+		if applicationId := config.getAndroidApplicationId(); applicationId != testCase.applicationId {
+			t.Errorf("Incorrect application ID for flavor '%v', expected %v but was %v", testCase.flavorName, testCase.applicationId, applicationId)
+		}
+	}
+}
+
+func TestGradleBuildFileWithoutNamespace(t *testing.T) {
+	config := writeGradleProject(t, map[string]string{
+		"app/build.gradle.kts": "android {\n    namespace = \"${undefinedVariable}.app\"\n}\n",
+	})
+
+	if namespace := config.getAndroidNamespacePath(); namespace != "" {
+		t.Errorf("Incorrect namespace, expected none but was %v", namespace)
+	}
+	if len(config.Warnings) != 1 {
+		t.Errorf("Incorrect result, expected a warning about the namespace but got %v", config.Warnings)
+	}
+}
+
+func TestRemoveGradleComments(t *testing.T) {
+	content := "url \"https://example.com\" // comment\n/* block\ncomment */namespace 'a'"
+	if result := removeGradleComments(content); result != "url \"https://example.com\" \n\nnamespace 'a'" {
+		t.Errorf("Incorrect result: %q", result)
+	}
+}
+
+func TestGradleKotlinConfigModelPath(t *testing.T) {
+	testCases := []struct {
+		description string
+		files       map[string]string
+		stagedFiles map[string]string
+		flavorName  string
+		modelPath   string
+	}{
+		{
+			description: "java directory",
+			files:       map[string]string{"app/build.gradle": "android {\n    namespace 'com.example'\n}\n"},
+			modelPath:   "app/src/main/java/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "kotlin directory",
+			files: map[string]string{
+				"app/build.gradle": "android {\n    namespace 'com.example'\n}\n",
+				"app/src/main/kotlin/com/example/MainActivity.kt": "package com.example",
+			},
+			modelPath: "app/src/main/kotlin/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "kotlin directory of the main source set",
+			files: map[string]string{
+				"app/build.gradle": "android {\n    namespace 'com.example'\n}\n",
+				"app/src/main/kotlin/com/example/MainActivity.kt": "package com.example",
+			},
+			flavorName: "development",
+			modelPath:  "app/src/development/kotlin/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "java directory of the flavor",
+			files: map[string]string{
+				"app/build.gradle": "android {\n    namespace 'com.example'\n}\n",
+				"app/src/main/kotlin/com/example/MainActivity.kt":        "package com.example",
+				"app/src/development/java/com/example/DevelopmentApi.kt": "package com.example",
+			},
+			flavorName: "development",
+			modelPath:  "app/src/development/java/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "staged kotlin directory of the main source set",
+			files:       map[string]string{"app/build.gradle": "android {\n    namespace 'com.example'\n}\n"},
+			stagedFiles: map[string]string{"app/src/main/kotlin/com/example/OneginiConfigModel.kt": "package com.example"},
+			flavorName:  "development",
+			modelPath:   "app/src/development/kotlin/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "source sets of the main source set",
+			files: map[string]string{
+				"app/build.gradle": "android {\n    namespace 'com.example'\n    sourceSets {\n        main {\n" +
+					"            kotlin.srcDirs += ['src/main/kt']\n        }\n    }\n}\n",
+			},
+			flavorName: "development",
+			modelPath:  "app/src/development/kotlin/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "Groovy source sets",
+			files: map[string]string{
+				"app/build.gradle": "android {\n    namespace 'com.example'\n    sourceSets {\n        main {\n" +
+					"            java.srcDirs += ['src/main/java', \"$projectDir/src/main/kotlin\"]\n        }\n    }\n}\n",
+			},
+			modelPath: "app/src/main/kotlin/com/example/OneginiConfigModel.kt",
+		},
+		{
+			description: "Kotlin DSL source sets",
+			files: map[string]string{
+				"app/build.gradle.kts": "android {\n    namespace = \"com.example\"\n    sourceSets[\"main\"].kotlin.srcDir(\"src/main/kt\")\n" +
+					"    sourceSets {\n        getByName(\"test\") {\n            java.srcDir(\"src/test/kotlin\")\n        }\n    }\n}\n",
+			},
+			modelPath: "app/src/main/kt/com/example/OneginiConfigModel.kt",
+		},
+	}
+	for _, testCase := range testCases {
+		config := writeGradleProject(t, testCase.files)
+		for name, contents := range testCase.stagedFiles {
+			config.writeFile(filepath.Join(config.AppDir, filepath.FromSlash(name)), []byte(contents))
+		}
+		SetFlavorName(testCase.flavorName, config)
+		expected := filepath.ToSlash(filepath.Join(config.AppDir, testCase.modelPath))
+		if modelPath := config.getAndroidConfigModelKotlinPath(); modelPath != expected {
+			t.Errorf("Incorrect config model path for the %v, expected %v but was %v", testCase.description, expected, modelPath)
+		}
+		PrepareAndroidPaths(config, false)
+		for _, dir := range config.changeSet().dirs {
+			if dir != path.Dir(expected) {
+				t.Errorf("Incorrect result, the directory %v is created for the config model in %v", dir, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,275 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// the CFBundleURLName of the URL type that the configurator adds for the redirect URL
+	redirectUrlTypeName = "OneginiRedirectionURL"
+
+	associatedDomainsEntitlement = "com.apple.developer.associated-domains"
+)
+
+// UpdateIosInfoPlist registers the redirect URL with iOS. A custom scheme is added as a CFBundleURLTypes entry to the Info.plist of the app
+// target, an https redirect URL is handled through universal links so its host is added to the associated domains entitlement instead. The
+// Info.plist of a Cordova project is configured by the plugin.
+func UpdateIosInfoPlist(config *Config) error {
+	if !config.UpdateInfoPlist || config.ConfigureForCordova {
+		return nil
+	}
+
+	redirectUrl, err := parseRedirectUrl(config.Options.RedirectUrl)
+	if err != nil {
+		return err
+	}
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
+	}
+	project, err := loadXcodeProj(config, xcodeProjPath)
+	if err != nil {
+		return err
+	}
+
+	if redirectUrl.Scheme == "https" {
+		return addAssociatedDomain(config, project, "applinks:"+redirectUrl.Host)
+	}
+
+	infoPlistPaths, err := findInfoPlistPaths(config, project)
+	if err != nil {
+		return err
+	}
+	for _, infoPlistPath := range infoPlistPaths {
+		// the flavors of one run share the Info.plist, the scheme of a flavor that is configured before this one is kept
+		keepSchemes := config.isStaged(infoPlistPath)
+		if err := updatePlistFile(config, infoPlistPath, func(root *plistDict) {
+			setRedirectUrlType(root, redirectUrl.Scheme, keepSchemes)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatePlistFile decodes the property list, lets update change it and writes it back in its original format.
+func updatePlistFile(config *Config, plistPath string, update func(root *plistDict)) error {
+	contents, err := config.readFile(plistPath)
+	if err != nil {
+		return fmt.Errorf("cannot read '%v': %w", plistPath, err)
+	}
+	value, format, err := decodePlist(contents)
+	if err != nil {
+		return fmt.Errorf("cannot read '%v': %w", plistPath, err)
+	}
+	root, ok := value.(*plistDict)
+	if !ok {
+		return fmt.Errorf("cannot read '%v': the property list does not contain a dictionary", plistPath)
+	}
+
+	update(root)
+
+	updatedContents, err := encodePlist(root, format)
+	if err != nil {
+		return fmt.Errorf("cannot write '%v': %w", plistPath, err)
+	}
+	config.writeFile(plistPath, updatedContents)
+	return nil
+}
+
+// setRedirectUrlType adds the scheme to the URL type of the configurator, or replaces the schemes when the URL type exists unless the existing
+// schemes are kept. Nothing is added when another URL type already contains the scheme.
+func setRedirectUrlType(root *plistDict, scheme string, keepSchemes bool) {
+	urlTypesValue, _ := root.get("CFBundleURLTypes")
+	urlTypes, _ := urlTypesValue.([]interface{})
+
+	for _, urlTypeValue := range urlTypes {
+		urlType, ok := urlTypeValue.(*plistDict)
+		if !ok {
+			continue
+		}
+		if name, _ := urlType.get("CFBundleURLName"); name == redirectUrlTypeName {
+			schemes, _ := urlType.get("CFBundleURLSchemes")
+			if !keepSchemes {
+				urlType.set("CFBundleURLSchemes", []interface{}{scheme})
+			} else if !containsPlistString(schemes, scheme) {
+				schemesArray, _ := schemes.([]interface{})
+				urlType.set("CFBundleURLSchemes", append(schemesArray, scheme))
+			}
+			return
+		}
+	}
+	for _, urlTypeValue := range urlTypes {
+		if urlType, ok := urlTypeValue.(*plistDict); ok {
+			schemes, _ := urlType.get("CFBundleURLSchemes")
+			if containsPlistString(schemes, scheme) {
+				return
+			}
+		}
+	}
+
+	urlType := newPlistDict()
+	urlType.set("CFBundleTypeRole", "Editor")
+	urlType.set("CFBundleURLName", redirectUrlTypeName)
+	urlType.set("CFBundleURLSchemes", []interface{}{scheme})
+	root.set("CFBundleURLTypes", append(urlTypes, urlType))
+}
+
+func containsPlistString(value interface{}, expected string) bool {
+	array, _ := value.([]interface{})
+	for _, element := range array {
+		if element == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// addAssociatedDomain adds the domain to the entitlements file of the app target. When the target does not have an entitlements file yet one
+// is created next to its Info.plist and set as CODE_SIGN_ENTITLEMENTS of the target.
+func addAssociatedDomain(config *Config, project *pbxProject, domain string) error {
+	entitlementsPaths := buildSettingPaths(config, project, "CODE_SIGN_ENTITLEMENTS")
+	if len(entitlementsPaths) == 0 {
+		infoPlistPaths, err := findInfoPlistPaths(config, project)
+		if err != nil {
+			return err
+		}
+		entitlementsPath := path.Join(path.Dir(infoPlistPaths[0]), config.AppTarget+".entitlements")
+		if !config.fileExists(entitlementsPath) {
+			emptyEntitlements, _ := encodeXmlPlist(newPlistDict())
+			config.writeFile(entitlementsPath, emptyEntitlements)
+		}
+		if err := setTargetBuildSetting(config, project, "CODE_SIGN_ENTITLEMENTS", entitlementsPath); err != nil {
+			return err
+		}
+		entitlementsPaths = []string{entitlementsPath}
+	}
+
+	for _, entitlementsPath := range entitlementsPaths {
+		if err := updatePlistFile(config, entitlementsPath, func(root *plistDict) {
+			domains, _ := root.get(associatedDomainsEntitlement)
+			if !containsPlistString(domains, domain) {
+				domainsArray, _ := domains.([]interface{})
+				root.set(associatedDomainsEntitlement, append(domainsArray, domain))
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findInfoPlistPaths returns the Info.plist files of the app target. They are read from the INFOPLIST_FILE build setting of the target, when
+// the target does not have that setting the Info.plist is looked up in the target directory.
+func findInfoPlistPaths(config *Config, project *pbxProject) ([]string, error) {
+	if infoPlistPaths := buildSettingPaths(config, project, "INFOPLIST_FILE"); len(infoPlistPaths) > 0 {
+		return infoPlistPaths, nil
+	}
+
+	projectDir := path.Dir(project.path)
+	candidates := []string{
+		path.Join(projectDir, config.AppTarget, "Info.plist"),
+		path.Join(getPlatformSpecificIosSrcPath(config), "Info.plist"),
+		path.Join(projectDir, "Info.plist"),
+	}
+	for _, candidate := range candidates {
+		if config.fileExists(candidate) {
+			return []string{candidate}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: the target '%v' does not have an INFOPLIST_FILE build setting and there is no '%v'", ErrInfoPlistNotFound, config.AppTarget, candidates[0])
+}
+
+// buildSettingPaths returns the distinct paths in the build setting of all build configurations of the app target, or of the project when
+// the target does not override the setting.
+func buildSettingPaths(config *Config, project *pbxProject, setting string) (paths []string) {
+	buildSettingsList := project.targetBuildSettings(config.AppTarget)
+	if !hasBuildSetting(buildSettingsList, setting) {
+		buildSettingsList = project.buildSettings(stringValue(project.rootObject(), "buildConfigurationList"))
+	}
+	for _, buildSettings := range buildSettingsList {
+		value, _ := buildSettings[setting].(string)
+		if len(value) == 0 {
+			continue
+		}
+		settingPath := resolveBuildSettingPath(value, path.Dir(project.path), config.AppTarget)
+		if !containsString(paths, settingPath) {
+			paths = append(paths, settingPath)
+		}
+	}
+	return
+}
+
+func setTargetBuildSetting(config *Config, project *pbxProject, setting string, filePath string) error {
+	targetBuildSettings := project.targetBuildSettings(config.AppTarget)
+	if len(targetBuildSettings) == 0 {
+		return fmt.Errorf("cannot set %v: the Xcode project does not contain a target named '%v' with build configurations", setting, config.AppTarget)
+	}
+	relativePath, err := filepath.Rel(path.Dir(project.path), filePath)
+	if err != nil {
+		return fmt.Errorf("cannot set %v: %w", setting, err)
+	}
+	for _, buildSettings := range targetBuildSettings {
+		buildSettings[setting] = filepath.ToSlash(relativePath)
+	}
+	config.recordXcodeEdit(fmt.Sprintf("set %v of target '%v' to '%v'", setting, config.AppTarget, filepath.ToSlash(relativePath)))
+	saveXcodeProj(config, project)
+	return nil
+}
+
+// resolveBuildSettingPath resolves a path in a build setting, which is relative to the project directory unless it starts with a variable.
+func resolveBuildSettingPath(value string, projectDir string, targetName string) string {
+	replacer := strings.NewReplacer(
+		"$(SRCROOT)", projectDir, "${SRCROOT}", projectDir,
+		"$(PROJECT_DIR)", projectDir, "${PROJECT_DIR}", projectDir,
+		"$(TARGET_NAME)", targetName, "${TARGET_NAME}", targetName,
+	)
+	resolved := replacer.Replace(strings.Trim(value, `"`))
+	if path.IsAbs(resolved) {
+		return path.Clean(resolved)
+	}
+	return path.Join(projectDir, resolved)
+}
+
+func hasBuildSetting(buildSettingsList []map[string]interface{}, setting string) bool {
+	for _, buildSettings := range buildSettingsList {
+		if _, ok := buildSettings[setting]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (project *pbxProject) targetBuildSettings(targetName string) (buildSettings []map[string]interface{}) {
+	for _, targetId := range project.targets(targetName) {
+		buildSettings = append(buildSettings, project.buildSettings(stringValue(project.object(targetId), "buildConfigurationList"))...)
+	}
+	return
+}
+
+// buildSettings returns the build settings of every build configuration in the configuration list.
+func (project *pbxProject) buildSettings(configurationListId string) (buildSettings []map[string]interface{}) {
+	for _, configurationId := range arrayValue(project.object(configurationListId), "buildConfigurations") {
+		if settings, ok := project.object(configurationId)["buildSettings"].(map[string]interface{}); ok {
+			buildSettings = append(buildSettings, settings)
+		}
+	}
+	return
+}
@@ -0,0 +1,130 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newInfoPlistTestConfig(t *testing.T, redirectUrl string, infoPlist []byte) *Config {
+	appDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(appDir, "Example.xcodeproj"), os.ModePerm)
+	_ = os.MkdirAll(filepath.Join(appDir, "Example"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(appDir, "Example.xcodeproj", "project.pbxproj"), []byte(examplePbxproj), 0644)
+	_ = os.WriteFile(filepath.Join(appDir, "Example", "Info.plist"), infoPlist, 0644)
+
+	return &Config{AppDir: appDir, AppTarget: "Example", UpdateInfoPlist: true, Options: &options{RedirectUrl: redirectUrl}}
+}
+
+func readTestPlist(t *testing.T, config *Config, plistPath string) (*plistDict, string) {
+	contents, err := config.readFile(plistPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	value, format, err := decodePlist(contents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return value.(*plistDict), format
+}
+
+func TestUpdateIosInfoPlistAddsUrlType(t *testing.T) {
+	config := newInfoPlistTestConfig(t, "example://loginsuccess", []byte(exampleXmlPlist))
+	if err := UpdateIosInfoPlist(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	infoPlistPath := filepath.Join(config.AppDir, "Example", "Info.plist")
+	root, format := readTestPlist(t, config, infoPlistPath)
+	urlTypes := root.values["CFBundleURLTypes"].([]interface{})
+	if format != plistFormatXml || len(urlTypes) != 1 {
+		t.Fatalf("Incorrect result, unexpected URL types %v in %v format", urlTypes, format)
+	}
+	urlType := urlTypes[0].(*plistDict)
+	if urlType.values["CFBundleURLName"] != redirectUrlTypeName || !reflect.DeepEqual(urlType.values["CFBundleURLSchemes"], []interface{}{"example"}) {
+		t.Errorf("Incorrect result, unexpected URL type %v", urlType.values)
+	}
+
+	// the scheme of another flavor in the same run is added to the URL type
+	flavorConfig := &Config{AppDir: config.AppDir, AppTarget: "Example", UpdateInfoPlist: true, Options: &options{RedirectUrl: "flavor://loginsuccess"}}
+	flavorConfig.ShareChanges(config)
+	if err := UpdateIosInfoPlist(flavorConfig); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	root, _ = readTestPlist(t, config, infoPlistPath)
+	urlTypes = root.values["CFBundleURLTypes"].([]interface{})
+	if len(urlTypes) != 1 || !reflect.DeepEqual(urlTypes[0].(*plistDict).values["CFBundleURLSchemes"], []interface{}{"example", "flavor"}) {
+		t.Errorf("Incorrect result, unexpected URL types %v", urlTypes)
+	}
+
+	// a different scheme in a later run replaces the schemes of the URL type
+	if err := ApplyChanges(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	config = &Config{AppDir: config.AppDir, AppTarget: "Example", UpdateInfoPlist: true, Options: &options{RedirectUrl: "other://loginsuccess"}}
+	if err := UpdateIosInfoPlist(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	root, _ = readTestPlist(t, config, infoPlistPath)
+	urlTypes = root.values["CFBundleURLTypes"].([]interface{})
+	if len(urlTypes) != 1 || !reflect.DeepEqual(urlTypes[0].(*plistDict).values["CFBundleURLSchemes"], []interface{}{"other"}) {
+		t.Errorf("Incorrect result, unexpected URL types %v", urlTypes)
+	}
+}
+
+func TestUpdateIosInfoPlistKeepsBinaryFormat(t *testing.T) {
+	binaryPlist, _ := hex.DecodeString(exampleBinaryPlist)
+	config := newInfoPlistTestConfig(t, "example://loginsuccess", binaryPlist)
+	if err := UpdateIosInfoPlist(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	root, format := readTestPlist(t, config, filepath.Join(config.AppDir, "Example", "Info.plist"))
+	if format != plistFormatBinary || root.values["CFBundleURLTypes"] == nil || root.values["Größe"] != "ünïcode" {
+		t.Errorf("Incorrect result, unexpected %v plist %v", format, root.values)
+	}
+}
+
+func TestUpdateIosInfoPlistAddsAssociatedDomain(t *testing.T) {
+	config := newInfoPlistTestConfig(t, "https://www.example.com/login-success", []byte(exampleXmlPlist))
+	// move the build configuration from the project to the target
+	pbxprojPath := filepath.Join(config.AppDir, "Example.xcodeproj", "project.pbxproj")
+	pbxproj := strings.Replace(examplePbxproj, "buildConfigurations = (\n\t\t\t\t1A000000000000000000000C /* Debug */,\n\t\t\t);", "buildConfigurations = (\n\t\t\t);", 1)
+	pbxproj = strings.Replace(pbxproj, "buildConfigurations = (\n\t\t\t);\n\t\t\tdefaultConfigurationIsVisible = 0;\n\t\t};",
+		"buildConfigurations = (\n\t\t\t\t1A000000000000000000000C /* Debug */,\n\t\t\t);\n\t\t\tdefaultConfigurationIsVisible = 0;\n\t\t};", 1)
+	_ = os.WriteFile(pbxprojPath, []byte(pbxproj), 0644)
+	if err := UpdateIosInfoPlist(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	root, _ := readTestPlist(t, config, filepath.Join(config.AppDir, "Example", "Example.entitlements"))
+	if !reflect.DeepEqual(root.values[associatedDomainsEntitlement], []interface{}{"applinks:www.example.com"}) {
+		t.Errorf("Incorrect result, unexpected entitlements %v", root.values)
+	}
+	updatedPbxproj, _ := config.readFile(pbxprojPath)
+	if !bytes.Contains(updatedPbxproj, []byte("CODE_SIGN_ENTITLEMENTS = Example/Example.entitlements;")) {
+		t.Errorf("Incorrect result, expected CODE_SIGN_ENTITLEMENTS to be set in:\n%v", string(updatedPbxproj))
+	}
+	infoPlist, _ := config.readFile(filepath.Join(config.AppDir, "Example", "Info.plist"))
+	if strings.Contains(string(infoPlist), "CFBundleURLTypes") {
+		t.Errorf("Incorrect result, expected no URL type for an https redirect URL")
+	}
+}
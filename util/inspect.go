@@ -0,0 +1,190 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// Inspection describes the contents of a Token Server configuration zip.
+type Inspection struct {
+	Options         *options             `json:"options"`
+	Certificates    []CertificateDetails `json:"certificates"`
+	ServerPublicKey PublicKeyDetails     `json:"server_public_key"`
+	Findings        []string             `json:"findings"`
+}
+
+type CertificateDetails struct {
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	NotBefore time.Time `json:"not_before,omitempty"`
+	NotAfter  time.Time `json:"not_after,omitempty"`
+	IsCA      bool      `json:"is_ca"`
+	SHA256    string    `json:"sha256,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type PublicKeyDetails struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	Size      int    `json:"size,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// InspectConfig describes the options, certificates and server public key in the config. Certificates and keys that cannot be decoded
+// are described by their error, so that a broken configuration zip can be inspected as well. Everything that would stop or warn about a
+// configuration with this zip is reported as a finding, certificates that expire within the threshold included.
+func InspectConfig(config *Config, expiryThreshold time.Duration) *Inspection {
+	inspection := &Inspection{Options: config.Options, Certificates: []CertificateDetails{}, Findings: []string{}}
+	addFindings := func(err error) {
+		if joinedErr, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, err := range joinedErr.Unwrap() {
+				inspection.Findings = append(inspection.Findings, err.Error())
+			}
+		} else if err != nil {
+			inspection.Findings = append(inspection.Findings, err.Error())
+		}
+	}
+
+	// the checks add their warnings to the config, collect them as findings without printing them
+	existingWarnings := len(config.Warnings)
+	defer func(quietWarnings bool) { config.quietWarnings = quietWarnings }(config.quietWarnings)
+	config.quietWarnings = true
+
+	// the same checks as VerifyTsZipContents, but all of them
+	if config.Options == nil {
+		// the zip does not contain a config.json, describe its certificates against empty options
+		addFindings(ErrInvalidConfigZip)
+		inspection.Options = &options{}
+	} else {
+		if len(config.Options.ResourceGatewayUris) == 0 {
+			addFindings(ErrMissingResourceGateway)
+		}
+		addFindings(ValidateOptions(config, ""))
+	}
+	if len(config.Certs) == 0 {
+		addFindings(ErrMissingCertificates)
+	}
+	addFindings(validateCertificates(config, expiryThreshold, time.Now()))
+
+	inspection.Findings = append(inspection.Findings, config.Warnings[existingWarnings:]...)
+
+	for _, certName := range sortedKeys(config.Certs) {
+		details := CertificateDetails{Name: certName}
+		if cert, err := parseCertificate(certName, config.Certs[certName]); err != nil {
+			details.Error = err.Error()
+		} else {
+			details.Subject = cert.Subject.String()
+			details.Issuer = cert.Issuer.String()
+			details.NotBefore = cert.NotBefore
+			details.NotAfter = cert.NotAfter
+			details.IsCA = cert.IsCA
+			details.SHA256 = certificateFingerprint(cert)
+		}
+		inspection.Certificates = append(inspection.Certificates, details)
+	}
+
+	inspection.ServerPublicKey = inspectServerPublicKey(inspection.Options.ServerPublicKey)
+	return inspection
+}
+
+func inspectServerPublicKey(publicKey serverPublicKey) (details PublicKeyDetails) {
+	if len(publicKey.Encoded) == 0 {
+		details.Error = "no server public key configured"
+		return
+	}
+
+	publicKeyBytes, err := base64.StdEncoding.DecodeString(publicKey.Encoded)
+	if err != nil {
+		details.Error = fmt.Sprintf("the server public key is not base64 encoded: %v", err)
+		return
+	}
+	parsedKey, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	if err != nil {
+		details.Error = fmt.Sprintf("cannot decode the server public key: %v", err)
+		return
+	}
+
+	switch key := parsedKey.(type) {
+	case *rsa.PublicKey:
+		details.Algorithm, details.Size = "RSA", key.N.BitLen()
+	case *ecdsa.PublicKey:
+		details.Algorithm, details.Size = "EC", key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		details.Algorithm, details.Size = "Ed25519", 256
+	default:
+		details.Error = fmt.Sprintf("unsupported server public key type %T", parsedKey)
+	}
+	return
+}
+
+func PrintInspection(inspection *Inspection) {
+	options := inspection.Options
+
+	fmt.Println("CONFIGURATION")
+	fmt.Printf("App Identifier:		%v\n", options.AppID)
+	fmt.Printf("App Platform:		%v\n", options.AppPlatform)
+	fmt.Printf("App Version:		%v\n", options.AppVersion)
+	fmt.Printf("Redirect URI:		%v\n", options.RedirectUrl)
+	fmt.Printf("Token Server URI:	%v\n", options.TokenServerUri)
+	fmt.Printf("Server type:		%v\n", options.ServerType)
+	fmt.Printf("Server version:		%v\n", options.ServerVersion)
+	fmt.Printf("Max PIN failures:	%v\n", options.MaxPinFailures)
+	for i, rgUri := range options.ResourceGatewayUris {
+		if i == 0 {
+			fmt.Printf("Resource Gateways:	%v\n", rgUri)
+		} else {
+			fmt.Printf("			%v\n", rgUri)
+		}
+	}
+
+	fmt.Print("\nSERVER PUBLIC KEY\n")
+	fmt.Printf("Algorithm:		%v\n", options.ServerPublicKey.Algorithm)
+	fmt.Printf("Encoded:		%v\n", options.ServerPublicKey.Encoded)
+	if len(inspection.ServerPublicKey.Error) > 0 {
+		fmt.Printf("Error:			%v\n", inspection.ServerPublicKey.Error)
+	} else {
+		fmt.Printf("Decoded:		%v, %v bits\n", inspection.ServerPublicKey.Algorithm, inspection.ServerPublicKey.Size)
+	}
+
+	fmt.Print("\nCERTIFICATES\n")
+	for _, cert := range inspection.Certificates {
+		fmt.Printf("\n%v\n", cert.Name)
+		if len(cert.Error) > 0 {
+			fmt.Printf("Error:			%v\n", cert.Error)
+			continue
+		}
+		fmt.Printf("Subject:		%v\n", cert.Subject)
+		fmt.Printf("Issuer:			%v\n", cert.Issuer)
+		fmt.Printf("Valid from:		%v\n", cert.NotBefore.Format(time.RFC3339))
+		fmt.Printf("Valid until:		%v\n", cert.NotAfter.Format(time.RFC3339))
+		fmt.Printf("CA:			%v\n", cert.IsCA)
+		fmt.Printf("SHA-256:		%v\n", cert.SHA256)
+	}
+
+	fmt.Print("\nFINDINGS\n")
+	if len(inspection.Findings) == 0 {
+		fmt.Println("None")
+	}
+	for _, finding := range inspection.Findings {
+		fmt.Printf("- %v\n", finding)
+	}
+}
@@ -0,0 +1,143 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateTestCertificate returns a PEM encoded self signed certificate for the given common name and validity.
+func generateTestCertificate(t *testing.T, commonName string, notBefore time.Time, notAfter time.Time, isCA bool) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestInspectConfig(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	publicKey, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+
+	notAfter := time.Date(2036, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := &Config{
+		Options: &options{ServerPublicKey: serverPublicKey{Encoded: base64.StdEncoding.EncodeToString(publicKey), Algorithm: "EC"}},
+		Certs: map[string]string{
+			"root.cer":    generateTestCertificate(t, "Test Root CA", time.Now(), notAfter, true),
+			"invalid.cer": "not a certificate",
+		},
+	}
+
+	inspection := InspectConfig(config, 0)
+
+	if len(inspection.Certificates) != 2 {
+		t.Fatalf("Incorrect number of certificates: %v", inspection.Certificates)
+	}
+	if inspection.Certificates[0].Name != "invalid.cer" || inspection.Certificates[0].Error == "" {
+		t.Errorf("Incorrect result, the invalid certificate should be reported: %+v", inspection.Certificates[0])
+	}
+	root := inspection.Certificates[1]
+	if root.Subject != "CN=Test Root CA" || root.Issuer != "CN=Test Root CA" || !root.IsCA || !root.NotAfter.Equal(notAfter) || len(root.SHA256) != 95 {
+		t.Errorf("Incorrect certificate details: %+v", root)
+	}
+	if inspection.ServerPublicKey.Algorithm != "EC" || inspection.ServerPublicKey.Size != 256 {
+		t.Errorf("Incorrect server public key details: %+v", inspection.ServerPublicKey)
+	}
+
+	config.Options.ServerPublicKey.Encoded = "not a key"
+	if details := inspectServerPublicKey(config.Options.ServerPublicKey); details.Error == "" {
+		t.Errorf("Incorrect result, an invalid server public key should be reported")
+	}
+}
+
+func TestInspectIncompleteConfig(t *testing.T) {
+	config := &Config{
+		Options: &options{AppID: "MyApp", TokenServerUri: "http://localhost:8080", RedirectUrl: "myapp://loginsuccess", ServerType: "onegini"},
+		Certs:   map[string]string{},
+	}
+
+	inspection := InspectConfig(config, 0)
+
+	expectedFindings := []string{
+		ErrMissingResourceGateway.Error(),
+		"option 'token_server_uri': 'http://localhost:8080' must use https",
+		ErrMissingCertificates.Error(),
+	}
+	if strings.Join(inspection.Findings, "\n") != strings.Join(expectedFindings, "\n") {
+		t.Errorf("Incorrect findings: %q", inspection.Findings)
+	}
+	if inspection.ServerPublicKey.Error == "" {
+		t.Errorf("Incorrect result, the missing server public key should be reported: %+v", inspection.ServerPublicKey)
+	}
+
+	inspection = InspectConfig(&Config{Certs: map[string]string{}}, 0)
+	if len(inspection.Findings) != 2 || inspection.Findings[0] != ErrInvalidConfigZip.Error() || inspection.Options == nil {
+		t.Errorf("Incorrect result, a zip without config.json should be inspected: %+v", inspection)
+	}
+}
+
+// should report warnings as findings only, instead of printing them as well
+func TestInspectConfigDoesNotPrintWarnings(t *testing.T) {
+	stderrPath := filepath.Join(t.TempDir(), "stderr")
+	stderr, err := os.Create(stderrPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	originalStderr := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = originalStderr }()
+
+	config := &Config{
+		Options: &options{AppID: "MyApp", TokenServerUri: "https://onegini.example.com", RedirectUrl: "myapp://loginsuccess", ServerType: "unknown",
+			ResourceGatewayUris: []string{"https://onegini.example.com/resources"}},
+		Certs: map[string]string{"root.cer": generateTestCertificate(t, "Test Root CA", time.Now(), time.Now().AddDate(10, 0, 0), true)},
+	}
+	inspection := InspectConfig(config, 0)
+	_ = stderr.Close()
+
+	if len(inspection.Findings) != 1 || !strings.Contains(inspection.Findings[0], "'unknown'") {
+		t.Errorf("Incorrect findings: %q", inspection.Findings)
+	}
+	if printed, _ := os.ReadFile(stderrPath); len(printed) != 0 {
+		t.Errorf("Incorrect result, the warnings should not be printed during the inspection: %v", string(printed))
+	}
+	if config.quietWarnings {
+		t.Errorf("Incorrect result, the warnings should be printed again after the inspection")
+	}
+}
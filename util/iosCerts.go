@@ -16,7 +16,6 @@ package util
 
 import (
 	"encoding/base64"
-	"encoding/pem"
 	"os"
 
 	"fmt"
@@ -24,55 +23,56 @@ import (
 	"strings"
 )
 
-func ConfigureIOSCertificates(config *Config) {
+func ConfigureIOSCertificates(config *Config) error {
 	storeDir := config.getIosXcodeCertificatePath()
-	xcodeProjPath := config.getIosXcodeProjPath()
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
+	}
 
-	removeOldCerts(storeDir, xcodeProjPath)
+	return removeOldCerts(config, storeDir, xcodeProjPath)
 }
 
-func removeOldCerts(storeDir string, xcodeProjPath string) {
+func removeOldCerts(config *Config, storeDir string, xcodeProjPath string) error {
 	d, err := os.Open(storeDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return
+			return nil
 		} else {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot open certificate Store dir: %v\n", err.Error()))
-			os.Exit(1)
+			return fmt.Errorf("cannot open certificate Store dir: %w", err)
 		}
 	}
 	defer d.Close()
 
 	fileInfo, err := d.Readdir(-1)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot remove old certs: %v\n", err.Error()))
-		os.Exit(1)
+		return fmt.Errorf("cannot remove old certs: %w", err)
 	}
 
 	for _, file := range fileInfo {
 		if file.Mode().IsRegular() && strings.HasSuffix(file.Name(), ".cer") {
 			filePath := storeDir + string(filepath.Separator) + file.Name()
-			os.Remove(filePath)
-			iosRemoveCertFilesFromXcodeProj(filePath, xcodeProjPath)
+			config.removeFile(filePath)
+			if err := iosRemoveCertFilesFromXcodeProj(config, filePath, xcodeProjPath); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func getBase64Certs(config *Config) []string {
+func getBase64Certs(config *Config) ([]string, error) {
 	var base64Certs []string
 
-	for cert, certContents := range config.Certs {
-		if !strings.HasPrefix(certContents, "-----BEGIN CERTIFICATE-----") {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: The '%v' certificate file provided in the Token Server configuration zip does not have the correct format.\n", cert))
-			os.Stderr.WriteString(fmt.Sprint("ERROR: Make sure that it is a PEM encoded certificate. All cert files should start with '-----BEGIN CERTIFICATE-----'\n\n"))
-			os.Exit(1)
+	for _, certName := range sortedKeys(config.Certs) {
+		cert, err := parseCertificate(certName, config.Certs[certName])
+		if err != nil {
+			return nil, err
 		}
 
-		block, _ := pem.Decode([]byte(certContents))
-
-		base64Cert := base64.StdEncoding.EncodeToString(block.Bytes)
+		base64Cert := base64.StdEncoding.EncodeToString(cert.Raw)
 		base64Certs = append(base64Certs, base64Cert)
 	}
 
-	return base64Certs
+	return base64Certs, nil
 }
@@ -16,102 +16,75 @@ package util
 
 import (
 	"io/ioutil"
-	"os"
-	"os/exec"
-	"path"
+	"time"
 
 	"crypto/sha256"
 	"fmt"
 
 	"crypto/rand"
 	"encoding/base64"
-
-	"path/filepath"
-
-	"github.com/onewelcome/sdk-configurator/data"
 )
 
-func CreateKeystore(config *Config) {
+func CreateKeystore(config *Config) error {
 	storePath := config.getAndroidKeystorePath()
 
-	_, err := os.Stat(storePath)
-	if err == nil {
-		os.Remove(storePath)
+	if config.fileExists(storePath) {
+		config.removeFile(storePath)
+	}
+
+	keystorePassword, err := generateKeystorePassword(2048)
+	if err != nil {
+		return err
 	}
 
-	keystorePassword := generateKeystorePassword(2048)
-	bcprovPath := restoreBcprov()
-	keytoolPath := findKeytool()
-
-	for certName, certContents := range config.Certs {
-		cmdKeytool := exec.Command(
-			keytoolPath,
-			"-import",
-			"-alias", certName,
-			"-keystore", storePath,
-			"-storepass", keystorePassword,
-			"-providerpath", bcprovPath,
-			"-storetype", "BKS",
-			"-provider", "org.bouncycastle.jce.provider.BouncyCastleProvider",
-			"-noprompt",
-		)
-		cmdStdinPipe, _ := cmdKeytool.StdinPipe()
-		cmdStdinPipe.Write([]byte(certContents))
-		cmdStdinPipe.Close()
-
-		_, err := cmdKeytool.CombinedOutput()
+	var certs []bksCertificate
+	for _, certName := range sortedKeys(config.Certs) {
+		cert, err := parseCertificate(certName, config.Certs[certName])
 		if err != nil {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: could not execute keytool: %v\n", err.Error()))
-			os.Exit(1)
+			return err
 		}
+		certs = append(certs, bksCertificate{alias: certName, der: cert.Raw})
 	}
-}
 
-func findKeytool() (keyToolPath string) {
-	keyToolPath, lookErr := exec.LookPath("keytool")
-	if lookErr != nil {
-		keyToolInJavaHome := path.Join(os.Getenv("JAVA_HOME"), "bin")
-		keyToolPath, _ = exec.LookPath(keyToolInJavaHome + string(filepath.Separator) + "keytool")
+	keystore, err := encodeBksKeystore(certs, keystorePassword, time.Now())
+	if err != nil {
+		return fmt.Errorf("could not create keystore: %w", err)
 	}
 
-	if _, err := os.Stat(keyToolPath); err != nil {
-		os.Stderr.WriteString(fmt.Sprintln("ERROR: Could not find keytool utility in your $PATH or $JAVA_HOME/bin.\n\nSee https://docs.oracle.com/cd/E19182-01/820-7851/inst_cli_jdk_javahome_t for instructions on how to set $JAVA_HOME"))
-		os.Exit(1)
-	}
-	return keyToolPath
+	config.writeFile(storePath, keystore)
+	config.keystoreHash = hashKeystore(keystore)
+	return nil
 }
 
-func CalculateKeystoreHash(keystorePath string) (hash string) {
+func CalculateKeystoreHash(keystorePath string) (string, error) {
 	keystore, err := ioutil.ReadFile(keystorePath)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not calculate keystore hash: %v\n", err))
-		os.Exit(1)
+		return "", fmt.Errorf("could not calculate keystore hash: %w", err)
+	}
+	return hashKeystore(keystore), nil
+}
+
+func (config *Config) calculateKeystoreHash(keystorePath string) (string, error) {
+	keystore, err := config.readFile(keystorePath)
+	if err != nil {
+		return "", fmt.Errorf("could not calculate keystore hash: %w", err)
 	}
+	return hashKeystore(keystore), nil
+}
+
+func hashKeystore(keystore []byte) (hash string) {
 	rawHash := sha256.Sum256(keystore)
 	hash = fmt.Sprintf("%x", string(rawHash[:]))
 
 	return
 }
 
-func generateKeystorePassword(n int) string {
+func generateKeystorePassword(n int) (string, error) {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not generate random data: %v\n", err.Error()))
-		os.Exit(1)
-	}
-
-	return base64.URLEncoding.EncodeToString(b)
-}
-
-func restoreBcprov() (filePath string) {
-	tempPath := path.Join(os.TempDir(), "sdk-configurator")
-	filePath = path.Join(tempPath, "lib", "bcprov-jdk15on-1.46.jar")
-
-	if err := data.RestoreAsset(tempPath, "lib/bcprov-jdk15on-1.46.jar"); err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not restore required asset: %v\n", err))
-		os.Exit(1)
+		return "", fmt.Errorf("could not generate random data: %w", err)
 	}
 
-	return
+	return base64.URLEncoding.EncodeToString(b), nil
 }
@@ -0,0 +1,124 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// knownServerTypes are the server types that the configurator has seen in configuration zips, it is not a complete list so another server type
+// only results in a warning
+var knownServerTypes = []string{"onegini", "access"}
+
+var knownServerPublicKeyAlgorithms = []string{"RSA", "EC"}
+
+// the URI scheme syntax from RFC 3986
+var uriSchemeRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*$`)
+
+// ValidateOptions checks the options from the configuration zip against what the SDK expects, so that an invalid configuration is rejected
+// here instead of failing at runtime on the device. All violations are returned at once as OptionErrors. The application platform is not
+// checked when platform is empty, which is the case when configuring both platforms at once.
+func ValidateOptions(config *Config, platform string) error {
+	options := config.Options
+	var errs []error
+	addViolation := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &OptionError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(strings.TrimSpace(options.AppID)) == 0 {
+		addViolation("application_identifier", "must not be empty")
+	}
+
+	if len(platform) > 0 && !strings.EqualFold(options.AppPlatform, platform) {
+		addViolation("application_platform", "the configuration is meant for the '%v' platform but the '%v' platform is being configured", options.AppPlatform, platform)
+	}
+
+	if err := validateUrl(options.TokenServerUri, true); err != nil {
+		addViolation("token_server_uri", "%v", err)
+	}
+
+	for i, resourceGatewayUri := range options.ResourceGatewayUris {
+		if err := validateUrl(resourceGatewayUri, false); err != nil {
+			addViolation(fmt.Sprintf("resource_gateway_uri[%v]", i), "%v", err)
+		}
+	}
+
+	if err := validateRedirectUrl(options.RedirectUrl); err != nil {
+		addViolation("redirect_url", "%v", err)
+	}
+
+	if !containsString(knownServerTypes, options.ServerType) {
+		config.AddWarning(fmt.Sprintf("Unknown server type '%v' in the configuration zip, expected one of: %v", options.ServerType,
+			strings.Join(knownServerTypes, ", ")))
+	}
+
+	if len(options.ServerPublicKey.Encoded) > 0 {
+		if !containsString(knownServerPublicKeyAlgorithms, options.ServerPublicKey.Algorithm) {
+			addViolation("server_public_key.algorithm", "unsupported algorithm '%v', expected one of: %v", options.ServerPublicKey.Algorithm,
+				strings.Join(knownServerPublicKeyAlgorithms, ", "))
+		} else if details := inspectServerPublicKey(options.ServerPublicKey); len(details.Error) > 0 {
+			addViolation("server_public_key.encoded", "%v", details.Error)
+		} else if details.Algorithm != options.ServerPublicKey.Algorithm {
+			addViolation("server_public_key.algorithm", "the algorithm is '%v' but the encoded key is an %v key", options.ServerPublicKey.Algorithm,
+				details.Algorithm)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateUrl(rawUrl string, requireHttps bool) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || len(rawUrl) == 0 {
+		return fmt.Errorf("'%v' is not a valid URL", rawUrl)
+	}
+	if requireHttps && parsedUrl.Scheme != "https" {
+		return fmt.Errorf("'%v' must use https", rawUrl)
+	}
+	if parsedUrl.Scheme != "https" && parsedUrl.Scheme != "http" {
+		return fmt.Errorf("'%v' must use http or https", rawUrl)
+	}
+	if len(parsedUrl.Hostname()) == 0 {
+		return fmt.Errorf("'%v' does not contain a host", rawUrl)
+	}
+	return nil
+}
+
+func validateRedirectUrl(rawUrl string) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || len(rawUrl) == 0 {
+		return fmt.Errorf("'%v' is not a valid URL", rawUrl)
+	}
+	if !uriSchemeRegex.MatchString(parsedUrl.Scheme) {
+		return fmt.Errorf("'%v' does not contain a valid scheme", rawUrl)
+	}
+	if len(parsedUrl.Host) == 0 {
+		return fmt.Errorf("'%v' does not contain a host", rawUrl)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validTestOptions() *options {
+	return &options{
+		TokenServerUri:      "https://onegini.example.com",
+		AppID:               "ExampleApp",
+		AppPlatform:         "android",
+		ResourceGatewayUris: []string{"https://rs.example.com/resources"},
+		RedirectUrl:         "oneginiexample://loginsuccess",
+		ServerType:          "access",
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	if err := ValidateOptions(&Config{Options: validTestOptions()}, "android"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	invalidOptions := validTestOptions()
+	invalidOptions.AppID = " "
+	invalidOptions.TokenServerUri = "http://onegini.example.com"
+	invalidOptions.ResourceGatewayUris = []string{"https://rs.example.com", "ftp://rs.example.com"}
+	invalidOptions.RedirectUrl = "loginsuccess"
+	invalidOptions.ServerType = "unknown"
+	invalidOptions.ServerPublicKey = serverPublicKey{Encoded: "bm90IGEga2V5", Algorithm: "RSA"}
+
+	config := &Config{Options: invalidOptions}
+	err := ValidateOptions(config, "ios")
+
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Incorrect result, expected an invalid option error but was: %v", err)
+	}
+	expectedFields := []string{"application_identifier", "application_platform", "token_server_uri", "resource_gateway_uri[1]", "redirect_url",
+		"server_public_key.encoded"}
+	violations := strings.Split(err.Error(), "\n")
+	if len(violations) != len(expectedFields) {
+		t.Fatalf("Incorrect number of violations:\n%v", err)
+	}
+	for i, violation := range violations {
+		if !strings.HasPrefix(violation, "option '"+expectedFields[i]+"'") {
+			t.Errorf("Incorrect violation '%v', expected one for '%v'", violation, expectedFields[i])
+		}
+	}
+
+	// an unknown server type is not a violation, the known server types are not a complete list
+	if len(config.Warnings) != 1 || !strings.Contains(config.Warnings[0], "'unknown'") {
+		t.Errorf("Incorrect result, expected a warning for the unknown server type but got: %v", config.Warnings)
+	}
+}
@@ -14,20 +14,19 @@
 
 package util
 
-import "os"
+import (
+	"os"
+	"path"
+)
 
 func PrepareIosPaths(config *Config) {
-	configModelPath := config.getIosConfigModelPath()
-	if _, err := os.Stat(configModelPath); os.IsNotExist(err) {
-		os.MkdirAll(configModelPath, 0755)
-	}
+	config.mkdirAll(config.getIosConfigModelPath())
 }
 
-func PrepareAndroidPaths(config *Config) {
-	classpathPath := config.getAndroidClasspathPath()
-	if _, err := os.Stat(classpathPath); os.IsNotExist(err) {
-		os.MkdirAll(classpathPath, 0775)
-	}
+// PrepareAndroidPaths creates the directory of the config model that is written, which is in the Kotlin source directory for a Kotlin config
+// model when the module has one.
+func PrepareAndroidPaths(config *Config, generateJavaConfigModel bool) {
+	config.mkdirAll(path.Dir(config.getAndroidConfigModelPath(generateJavaConfigModel)))
 }
 
 func exists(path string) bool {
@@ -0,0 +1,582 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// The project.pbxproj file is an old-style (OpenStep) property list. Values are represented as strings, []interface{} for arrays,
+// map[string]interface{} for dictionaries and pbxData for the rarely used <hex> data values.
+type pbxData string
+
+type pbxParser struct {
+	data []byte
+	pos  int
+}
+
+func parsePbxproj(data []byte) (map[string]interface{}, error) {
+	parser := &pbxParser{data: data}
+	if err := parser.skipWhitespaceAndComments(); err != nil {
+		return nil, err
+	}
+
+	value, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the project file does not contain a dictionary")
+	}
+
+	if err := parser.skipWhitespaceAndComments(); err != nil {
+		return nil, err
+	}
+	if parser.pos != len(parser.data) {
+		return nil, parser.errorf("unexpected content after the root dictionary")
+	}
+
+	return root, nil
+}
+
+func (parser *pbxParser) errorf(format string, args ...interface{}) error {
+	line := bytes.Count(parser.data[:parser.pos], []byte("\n")) + 1
+	return fmt.Errorf("line %v: %v", line, fmt.Sprintf(format, args...))
+}
+
+func (parser *pbxParser) skipWhitespaceAndComments() error {
+	for parser.pos < len(parser.data) {
+		switch {
+		case strings.ContainsRune(" \t\r\n", rune(parser.data[parser.pos])):
+			parser.pos++
+		case bytes.HasPrefix(parser.data[parser.pos:], []byte("//")):
+			end := bytes.IndexByte(parser.data[parser.pos:], '\n')
+			if end < 0 {
+				parser.pos = len(parser.data)
+			} else {
+				parser.pos += end + 1
+			}
+		case bytes.HasPrefix(parser.data[parser.pos:], []byte("/*")):
+			end := bytes.Index(parser.data[parser.pos+2:], []byte("*/"))
+			if end < 0 {
+				return parser.errorf("unterminated comment")
+			}
+			parser.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (parser *pbxParser) expect(char byte) error {
+	if err := parser.skipWhitespaceAndComments(); err != nil {
+		return err
+	}
+	if parser.pos >= len(parser.data) || parser.data[parser.pos] != char {
+		return parser.errorf("expected '%c'", char)
+	}
+	parser.pos++
+	return nil
+}
+
+func (parser *pbxParser) peek() (byte, error) {
+	if err := parser.skipWhitespaceAndComments(); err != nil {
+		return 0, err
+	}
+	if parser.pos >= len(parser.data) {
+		return 0, parser.errorf("unexpected end of file")
+	}
+	return parser.data[parser.pos], nil
+}
+
+func (parser *pbxParser) parseValue() (interface{}, error) {
+	next, err := parser.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	switch next {
+	case '{':
+		return parser.parseDictionary()
+	case '(':
+		return parser.parseArray()
+	case '<':
+		end := bytes.IndexByte(parser.data[parser.pos:], '>')
+		if end < 0 {
+			return nil, parser.errorf("unterminated data value")
+		}
+		value := pbxData(parser.data[parser.pos+1 : parser.pos+end])
+		parser.pos += end + 1
+		return value, nil
+	default:
+		return parser.parseString()
+	}
+}
+
+func (parser *pbxParser) parseDictionary() (map[string]interface{}, error) {
+	parser.pos++
+	dictionary := make(map[string]interface{})
+
+	for {
+		next, err := parser.peek()
+		if err != nil {
+			return nil, err
+		}
+		if next == '}' {
+			parser.pos++
+			return dictionary, nil
+		}
+
+		key, err := parser.parseString()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect('='); err != nil {
+			return nil, err
+		}
+		value, err := parser.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect(';'); err != nil {
+			return nil, err
+		}
+		dictionary[key] = value
+	}
+}
+
+func (parser *pbxParser) parseArray() ([]interface{}, error) {
+	parser.pos++
+	array := make([]interface{}, 0)
+
+	for {
+		next, err := parser.peek()
+		if err != nil {
+			return nil, err
+		}
+		if next == ')' {
+			parser.pos++
+			return array, nil
+		}
+
+		value, err := parser.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+
+		next, err = parser.peek()
+		if err != nil {
+			return nil, err
+		}
+		if next == ',' {
+			parser.pos++
+		} else if next != ')' {
+			return nil, parser.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+func (parser *pbxParser) parseString() (string, error) {
+	if _, err := parser.peek(); err != nil {
+		return "", err
+	}
+	if parser.data[parser.pos] == '"' {
+		return parser.parseQuotedString()
+	}
+
+	start := parser.pos
+	for parser.pos < len(parser.data) && !strings.ContainsRune(" \t\r\n{}();=,\"<>", rune(parser.data[parser.pos])) {
+		parser.pos++
+	}
+	if start == parser.pos {
+		return "", parser.errorf("expected a value")
+	}
+	return string(parser.data[start:parser.pos]), nil
+}
+
+func (parser *pbxParser) parseQuotedString() (string, error) {
+	parser.pos++
+	var value strings.Builder
+
+	for parser.pos < len(parser.data) {
+		char := parser.data[parser.pos]
+		switch char {
+		case '"':
+			parser.pos++
+			return value.String(), nil
+		case '\\':
+			if parser.pos+1 >= len(parser.data) {
+				return "", parser.errorf("unterminated string")
+			}
+			escaped := parser.data[parser.pos+1]
+			parser.pos += 2
+			switch escaped {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case 'r':
+				value.WriteByte('\r')
+			case 'U':
+				if parser.pos+4 > len(parser.data) {
+					return "", parser.errorf("invalid unicode escape")
+				}
+				codePoint, err := strconv.ParseUint(string(parser.data[parser.pos:parser.pos+4]), 16, 32)
+				if err != nil {
+					return "", parser.errorf("invalid unicode escape")
+				}
+				value.WriteRune(rune(codePoint))
+				parser.pos += 4
+			default:
+				value.WriteByte(escaped)
+			}
+		default:
+			value.WriteByte(char)
+			parser.pos++
+		}
+	}
+
+	return "", parser.errorf("unterminated string")
+}
+
+// Serialization follows the layout Xcode itself uses, so that saving an unmodified project does not produce a diff.
+
+var (
+	pbxUnquotedStringRegexp = regexp.MustCompile(`^[A-Za-z0-9_$/:.]+$`)
+	pbxInlineObjectTypes    = map[string]bool{"PBXBuildFile": true, "PBXFileReference": true}
+	pbxDefaultPhaseNames    = map[string]string{
+		"PBXSourcesBuildPhase":     "Sources",
+		"PBXFrameworksBuildPhase":  "Frameworks",
+		"PBXResourcesBuildPhase":   "Resources",
+		"PBXHeadersBuildPhase":     "Headers",
+		"PBXCopyFilesBuildPhase":   "CopyFiles",
+		"PBXShellScriptBuildPhase": "ShellScript",
+		"PBXRezBuildPhase":         "Rez",
+	}
+)
+
+type pbxWriter struct {
+	buffer   bytes.Buffer
+	project  *pbxProject
+	comments map[string]string
+}
+
+func (project *pbxProject) serialize() []byte {
+	writer := &pbxWriter{project: project, comments: project.objectComments()}
+	writer.buffer.WriteString("// !$*UTF8*$!\n{\n")
+
+	for _, key := range sortedKeys(project.root) {
+		writer.buffer.WriteString("\t" + quotePbxString(key) + " = ")
+		if key == "objects" {
+			writer.writeObjects()
+		} else {
+			writer.writeValue(project.root[key], 1, false, key)
+		}
+		writer.buffer.WriteString(";\n")
+	}
+
+	writer.buffer.WriteString("}\n")
+	return writer.buffer.Bytes()
+}
+
+func (writer *pbxWriter) writeObjects() {
+	sections := make(map[string][]string)
+	for id, object := range writer.project.objects {
+		isa := ""
+		if dictionary, ok := object.(map[string]interface{}); ok {
+			isa, _ = dictionary["isa"].(string)
+		}
+		sections[isa] = append(sections[isa], id)
+	}
+
+	writer.buffer.WriteString("{\n")
+	for _, isa := range sortedKeys(sections) {
+		ids := sections[isa]
+		sort.Strings(ids)
+
+		writer.buffer.WriteString("\n/* Begin " + isa + " section */\n")
+		for _, id := range ids {
+			writer.buffer.WriteString("\t\t")
+			writer.writeString(id, "")
+			writer.buffer.WriteString(" = ")
+			writer.writeValue(writer.project.objects[id], 2, pbxInlineObjectTypes[isa], "")
+			writer.buffer.WriteString(";\n")
+		}
+		writer.buffer.WriteString("/* End " + isa + " section */\n")
+	}
+	writer.buffer.WriteString("\t}")
+}
+
+func (writer *pbxWriter) writeValue(value interface{}, indent int, inline bool, key string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		writer.writeDictionary(typed, indent, inline)
+	case []interface{}:
+		writer.writeArray(typed, indent, inline, key)
+	case pbxData:
+		writer.buffer.WriteString("<" + string(typed) + ">")
+	case string:
+		writer.writeString(typed, key)
+	}
+}
+
+func (writer *pbxWriter) writeDictionary(dictionary map[string]interface{}, indent int, inline bool) {
+	keys := sortedKeys(dictionary)
+	// Xcode always puts the object type first
+	if _, ok := dictionary["isa"]; ok {
+		keys = append([]string{"isa"}, removeString(keys, "isa")...)
+	}
+
+	writer.buffer.WriteString("{")
+	if !inline {
+		writer.buffer.WriteString("\n")
+	}
+	for _, key := range keys {
+		if !inline {
+			writer.buffer.WriteString(strings.Repeat("\t", indent+1))
+		}
+		writer.buffer.WriteString(quotePbxString(key) + " = ")
+		writer.writeValue(dictionary[key], indent+1, inline, key)
+		writer.buffer.WriteString(";")
+		if inline {
+			writer.buffer.WriteString(" ")
+		} else {
+			writer.buffer.WriteString("\n")
+		}
+	}
+	if !inline {
+		writer.buffer.WriteString(strings.Repeat("\t", indent))
+	}
+	writer.buffer.WriteString("}")
+}
+
+func (writer *pbxWriter) writeArray(array []interface{}, indent int, inline bool, key string) {
+	writer.buffer.WriteString("(")
+	if !inline {
+		writer.buffer.WriteString("\n")
+	}
+	for _, value := range array {
+		if !inline {
+			writer.buffer.WriteString(strings.Repeat("\t", indent+1))
+		}
+		writer.writeValue(value, indent+1, inline, key)
+		writer.buffer.WriteString(",")
+		if inline {
+			writer.buffer.WriteString(" ")
+		} else {
+			writer.buffer.WriteString("\n")
+		}
+	}
+	if !inline {
+		writer.buffer.WriteString(strings.Repeat("\t", indent))
+	}
+	writer.buffer.WriteString(")")
+}
+
+func (writer *pbxWriter) writeString(value string, key string) {
+	writer.buffer.WriteString(quotePbxString(value))
+	if key == "remoteGlobalIDString" {
+		return
+	}
+	if comment, ok := writer.comments[value]; ok {
+		writer.buffer.WriteString(" /* " + comment + " */")
+	}
+}
+
+func quotePbxString(value string) string {
+	if pbxUnquotedStringRegexp.MatchString(value) && !strings.Contains(value, "//") && !strings.Contains(value, "___") {
+		return value
+	}
+
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for _, char := range value {
+		switch char {
+		case '"':
+			quoted.WriteString(`\"`)
+		case '\\':
+			quoted.WriteString(`\\`)
+		case '\n':
+			quoted.WriteString(`\n`)
+		case '\t':
+			quoted.WriteString(`\t`)
+		default:
+			if char == utf8.RuneError {
+				continue
+			}
+			quoted.WriteRune(char)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
+
+func sortedKeys[V any](dictionary map[string]V) []string {
+	keys := make([]string, 0, len(dictionary))
+	for key := range dictionary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func removeString(values []string, value string) []string {
+	result := make([]string, 0, len(values))
+	for _, candidate := range values {
+		if candidate != value {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// objectComments computes the annotations Xcode writes after object identifiers.
+func (project *pbxProject) objectComments() map[string]string {
+	comments := make(map[string]string)
+	buildFilePhases := make(map[string]string)
+	configurationListOwners := make(map[string]string)
+	exceptionSetFolders := make(map[string]string)
+
+	for id := range project.objects {
+		object := project.object(id)
+		isa := stringValue(object, "isa")
+		if phaseName, isPhase := pbxDefaultPhaseNames[isa]; isPhase {
+			if name := stringValue(object, "name"); name != "" {
+				phaseName = name
+			}
+			for _, buildFile := range arrayValue(object, "files") {
+				buildFilePhases[buildFile] = phaseName
+			}
+		}
+		if list := stringValue(object, "buildConfigurationList"); list != "" {
+			ownerName := stringValue(object, "name")
+			if isa == "PBXProject" {
+				ownerName = strings.TrimSuffix(path.Base(project.path), ".xcodeproj")
+			}
+			configurationListOwners[list] = fmt.Sprintf("Build configuration list for %v \"%v\"", isa, ownerName)
+		}
+		if isa == "PBXFileSystemSynchronizedRootGroup" {
+			for _, exceptionSet := range arrayValue(object, "exceptions") {
+				exceptionSetFolders[exceptionSet] = project.displayName(id)
+			}
+		}
+	}
+
+	for id := range project.objects {
+		object := project.object(id)
+		isa := stringValue(object, "isa")
+		switch {
+		case isa == "PBXProject":
+			comments[id] = "Project object"
+		case isa == "XCConfigurationList":
+			comments[id] = configurationListOwners[id]
+		case isa == "PBXBuildFile":
+			fileName := project.displayName(stringValue(object, "fileRef"))
+			if fileName == "" {
+				fileName = project.displayName(stringValue(object, "productRef"))
+			}
+			comments[id] = fileName + " in " + buildFilePhases[id]
+		case pbxDefaultPhaseNames[isa] != "":
+			comments[id] = pbxDefaultPhaseNames[isa]
+			if name := stringValue(object, "name"); name != "" {
+				comments[id] = name
+			}
+		case isa == "PBXContainerItemProxy" || isa == "PBXTargetDependency":
+			comments[id] = isa
+		case isa == "XCRemoteSwiftPackageReference":
+			// Xcode names the package after the repository
+			repositoryName := strings.TrimSuffix(path.Base(stringValue(object, "repositoryURL")), ".git")
+			comments[id] = fmt.Sprintf("%v \"%v\"", isa, repositoryName)
+		case isa == "XCLocalSwiftPackageReference":
+			comments[id] = fmt.Sprintf("%v \"%v\"", isa, stringValue(object, "relativePath"))
+		case isa == "PBXFileSystemSynchronizedBuildFileExceptionSet":
+			targetName := project.displayName(stringValue(object, "target"))
+			comments[id] = fmt.Sprintf("Exceptions for \"%v\" folder in \"%v\" target", exceptionSetFolders[id], targetName)
+		default:
+			if name := project.displayName(id); name != "" {
+				comments[id] = name
+			}
+		}
+	}
+
+	return comments
+}
+
+func (project *pbxProject) displayName(id string) string {
+	object := project.object(id)
+	if object == nil {
+		return ""
+	}
+	if name := stringValue(object, "name"); name != "" {
+		return name
+	}
+	if productName := stringValue(object, "productName"); productName != "" && stringValue(object, "isa") == "XCSwiftPackageProductDependency" {
+		return productName
+	}
+	return stringValue(object, "path")
+}
+
+func stringValue(object map[string]interface{}, key string) string {
+	if object == nil {
+		return ""
+	}
+	value, _ := object[key].(string)
+	return value
+}
+
+func arrayValue(object map[string]interface{}, key string) []string {
+	if object == nil {
+		return nil
+	}
+	values, _ := object[key].([]interface{})
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func toInterfaceArray(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+func generatePbxObjectId(existing map[string]interface{}) string {
+	for {
+		id := make([]byte, 12)
+		_, _ = rand.Read(id)
+		candidate := strings.ToUpper(hex.EncodeToString(id))
+		if _, taken := existing[candidate]; !taken {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,259 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// trimmed down project.pbxproj as generated by Xcode for a new iOS app
+const examplePbxproj string = `// !$*UTF8*$!
+{
+	archiveVersion = 1;
+	classes = {
+	};
+	objectVersion = 56;
+	objects = {
+
+/* Begin PBXBuildFile section */
+		1A0000000000000000000001 /* AppDelegate.m in Sources */ = {isa = PBXBuildFile; fileRef = 1A0000000000000000000002 /* AppDelegate.m */; };
+/* End PBXBuildFile section */
+
+/* Begin PBXFileReference section */
+		1A0000000000000000000002 /* AppDelegate.m */ = {isa = PBXFileReference; lastKnownFileType = sourcecode.c.objc; path = AppDelegate.m; sourceTree = "<group>"; };
+		1A0000000000000000000003 /* Example.app */ = {isa = PBXFileReference; explicitFileType = wrapper.application; includeInIndex = 0; path = Example.app; sourceTree = BUILT_PRODUCTS_DIR; };
+/* End PBXFileReference section */
+
+/* Begin PBXGroup section */
+		1A0000000000000000000004 = {
+			isa = PBXGroup;
+			children = (
+				1A0000000000000000000005 /* Example */,
+				1A0000000000000000000006 /* Products */,
+			);
+			sourceTree = "<group>";
+		};
+		1A0000000000000000000005 /* Example */ = {
+			isa = PBXGroup;
+			children = (
+				1A0000000000000000000002 /* AppDelegate.m */,
+			);
+			path = Example;
+			sourceTree = "<group>";
+		};
+		1A0000000000000000000006 /* Products */ = {
+			isa = PBXGroup;
+			children = (
+				1A0000000000000000000003 /* Example.app */,
+			);
+			name = Products;
+			sourceTree = "<group>";
+		};
+/* End PBXGroup section */
+
+/* Begin PBXNativeTarget section */
+		1A0000000000000000000007 /* Example */ = {
+			isa = PBXNativeTarget;
+			buildConfigurationList = 1A000000000000000000000B /* Build configuration list for PBXNativeTarget "Example" */;
+			buildPhases = (
+				1A0000000000000000000008 /* Sources */,
+			);
+			buildRules = (
+			);
+			dependencies = (
+			);
+			name = Example;
+			productName = Example;
+			productReference = 1A0000000000000000000003 /* Example.app */;
+			productType = "com.apple.product-type.application";
+		};
+/* End PBXNativeTarget section */
+
+/* Begin PBXProject section */
+		1A0000000000000000000009 /* Project object */ = {
+			isa = PBXProject;
+			attributes = {
+				LastUpgradeCheck = 1500;
+			};
+			buildConfigurationList = 1A000000000000000000000A /* Build configuration list for PBXProject "Example" */;
+			compatibilityVersion = "Xcode 14.0";
+			mainGroup = 1A0000000000000000000004;
+			productRefGroup = 1A0000000000000000000006 /* Products */;
+			projectDirPath = "";
+			projectRoot = "";
+			targets = (
+				1A0000000000000000000007 /* Example */,
+			);
+		};
+/* End PBXProject section */
+
+/* Begin PBXSourcesBuildPhase section */
+		1A0000000000000000000008 /* Sources */ = {
+			isa = PBXSourcesBuildPhase;
+			buildActionMask = 2147483647;
+			files = (
+				1A0000000000000000000001 /* AppDelegate.m in Sources */,
+			);
+			runOnlyForDeploymentPostprocessing = 0;
+		};
+/* End PBXSourcesBuildPhase section */
+
+/* Begin XCBuildConfiguration section */
+		1A000000000000000000000C /* Debug */ = {
+			isa = XCBuildConfiguration;
+			buildSettings = {
+				"CODE_SIGN_IDENTITY[sdk=iphoneos*]" = "iPhone Developer";
+				INFOPLIST_FILE = Example/Info.plist;
+				OTHER_LDFLAGS = (
+					"$(inherited)",
+					"-ObjC",
+				);
+			};
+			name = Debug;
+		};
+/* End XCBuildConfiguration section */
+
+/* Begin XCConfigurationList section */
+		1A000000000000000000000A /* Build configuration list for PBXProject "Example" */ = {
+			isa = XCConfigurationList;
+			buildConfigurations = (
+				1A000000000000000000000C /* Debug */,
+			);
+			defaultConfigurationIsVisible = 0;
+			defaultConfigurationName = Debug;
+		};
+		1A000000000000000000000B /* Build configuration list for PBXNativeTarget "Example" */ = {
+			isa = XCConfigurationList;
+			buildConfigurations = (
+			);
+			defaultConfigurationIsVisible = 0;
+		};
+/* End XCConfigurationList section */
+	};
+	rootObject = 1A0000000000000000000009 /* Project object */;
+}
+`
+
+func writeExampleXcodeProj(t *testing.T) (appDir string, xcodeProjPath string) {
+	appDir = t.TempDir()
+	xcodeProjPath = filepath.Join(appDir, "Example.xcodeproj")
+	if err := os.MkdirAll(xcodeProjPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(xcodeProjPath, "project.pbxproj"), []byte(examplePbxproj), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func readExamplePbxproj(t *testing.T, config *Config, xcodeProjPath string) string {
+	contents, err := config.readFile(filepath.Join(xcodeProjPath, "project.pbxproj"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(contents)
+}
+
+// should write an unmodified project byte for byte identical to what Xcode writes
+func TestPbxprojRoundTrip(t *testing.T) {
+	_, xcodeProjPath := writeExampleXcodeProj(t)
+
+	project, err := parsePbxProject(xcodeProjPath, []byte(examplePbxproj))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result := string(project.serialize()); result != examplePbxproj {
+		t.Errorf("Incorrect result, the project should not be modified:\n%v", result)
+	}
+}
+
+// should keep the comments of Swift packages and of the folders that Xcode 16 synchronizes with the file system
+func TestPbxprojRoundTripWithSwiftPackagesAndSynchronizedFolders(t *testing.T) {
+	contents, err := os.ReadFile(filepath.Join("testdata", "SwiftPackages.pbxproj"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	project, err := parsePbxProject(filepath.Join(t.TempDir(), "Example.xcodeproj"), contents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result := string(project.serialize()); result != string(contents) {
+		t.Errorf("Incorrect result, the project should not be modified:\n%v", result)
+	}
+}
+
+func TestPbxprojAddAndRemoveFile(t *testing.T) {
+	appDir, xcodeProjPath := writeExampleXcodeProj(t)
+	config := new(Config)
+	modelPath := filepath.Join(appDir, "Configuration", "dev", "OneginiConfigModel.m")
+	headerPath := filepath.Join(appDir, "Configuration", "dev", "OneginiConfigModel.h")
+
+	for _, filePath := range []string{modelPath, headerPath, modelPath} {
+		// adding the same file twice should not create a second reference
+		if err := addFileToXcodeProj(config, filePath, xcodeProjPath, "Example", "Configuration", "dev"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	project, err := loadXcodeProj(config, xcodeProjPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	configurationGroup := project.childGroup(project.mainGroup(), "Configuration")
+	flavorGroup := project.childGroup(configurationGroup, "dev")
+	if configurationGroup == "" || flavorGroup == "" {
+		t.Fatalf("Incorrect result, the project should contain the Configuration/dev group:\n%v", readExamplePbxproj(t, config, xcodeProjPath))
+	}
+	modelFile := project.findFile(flavorGroup, modelPath)
+	if modelFile == "" || project.findFile(flavorGroup, headerPath) == "" {
+		t.Fatalf("Incorrect result, the project should contain the config model files:\n%v", readExamplePbxproj(t, config, xcodeProjPath))
+	}
+	if len(arrayValue(project.object(flavorGroup), "children")) != 2 {
+		t.Errorf("Incorrect result, the config model should only be added once:\n%v", readExamplePbxproj(t, config, xcodeProjPath))
+	}
+	if path := stringValue(project.object(modelFile), "path"); path != "Configuration/dev/OneginiConfigModel.m" {
+		t.Errorf("Incorrect file reference path '%v'", path)
+	}
+
+	result := readExamplePbxproj(t, config, xcodeProjPath)
+	if !strings.Contains(result, "/* OneginiConfigModel.m in Sources */,") || !strings.Contains(result, "/* OneginiConfigModel.h in Headers */,") {
+		t.Errorf("Incorrect result, the config model should be part of the target:\n%v", result)
+	}
+
+	for _, filePath := range []string{modelPath, headerPath} {
+		if err := removeFileFromXcodeProj(config, filePath, xcodeProjPath, "Configuration", "dev"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	result = readExamplePbxproj(t, config, xcodeProjPath)
+	if strings.Contains(result, "OneginiConfigModel") || strings.Contains(result, "/* dev */") {
+		t.Errorf("Incorrect result, the config model and its group should be removed:\n%v", result)
+	}
+	if !strings.Contains(result, "/* AppDelegate.m in Sources */,") {
+		t.Errorf("Incorrect result, other files should not be removed:\n%v", result)
+	}
+
+	// the changes are only staged until they are applied
+	if contents, _ := os.ReadFile(filepath.Join(xcodeProjPath, "project.pbxproj")); string(contents) != examplePbxproj {
+		t.Errorf("Incorrect result, the project on disk should not be modified before applying the changes:\n%v", string(contents))
+	}
+}
@@ -0,0 +1,601 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Property lists are decoded into *plistDict, []interface{}, string, int64, float64, bool, []byte and time.Time values. Dictionaries keep the
+// order of their keys, so a property list that is written again only changes where its values were changed.
+
+const (
+	plistFormatXml    = "xml"
+	plistFormatBinary = "binary"
+
+	binaryPlistHeader = "bplist00"
+)
+
+// binary property list dates are stored as seconds since 2001-01-01
+var plistReferenceDate = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type plistDict struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newPlistDict() *plistDict {
+	return &plistDict{values: make(map[string]interface{})}
+}
+
+func (dict *plistDict) get(key string) (interface{}, bool) {
+	value, ok := dict.values[key]
+	return value, ok
+}
+
+func (dict *plistDict) set(key string, value interface{}) {
+	if _, ok := dict.values[key]; !ok {
+		dict.keys = append(dict.keys, key)
+	}
+	dict.values[key] = value
+}
+
+// decodePlist decodes an XML or binary property list, the format is returned so the property list can be written in the same format.
+func decodePlist(contents []byte) (value interface{}, format string, err error) {
+	if bytes.HasPrefix(contents, []byte(binaryPlistHeader)) {
+		value, err = decodeBinaryPlist(contents)
+		return value, plistFormatBinary, err
+	}
+	value, err = decodeXmlPlist(contents)
+	return value, plistFormatXml, err
+}
+
+func encodePlist(value interface{}, format string) ([]byte, error) {
+	if format == plistFormatBinary {
+		return encodeBinaryPlist(value)
+	}
+	return encodeXmlPlist(value)
+}
+
+// XML property lists
+
+func decodeXmlPlist(contents []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(contents))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid property list: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "plist" {
+				return nil, fmt.Errorf("invalid property list: unexpected element <%v>", start.Name.Local)
+			}
+			break
+		}
+	}
+
+	start, err := nextXmlPlistElement(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return decodeXmlPlistValue(decoder, start)
+}
+
+func nextXmlPlistElement(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, fmt.Errorf("invalid property list: %w", err)
+		}
+		switch typedToken := token.(type) {
+		case xml.StartElement:
+			return typedToken, nil
+		case xml.EndElement:
+			return xml.StartElement{}, fmt.Errorf("invalid property list: unexpected </%v>", typedToken.Name.Local)
+		}
+	}
+}
+
+func decodeXmlPlistValue(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		dict := newPlistDict()
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("invalid property list: %w", err)
+			}
+			if _, ok := token.(xml.EndElement); ok {
+				return dict, nil
+			}
+			keyStart, ok := token.(xml.StartElement)
+			if !ok {
+				continue
+			}
+			if keyStart.Name.Local != "key" {
+				return nil, fmt.Errorf("invalid property list: expected <key> but found <%v>", keyStart.Name.Local)
+			}
+			var key string
+			if err := decoder.DecodeElement(&key, &keyStart); err != nil {
+				return nil, fmt.Errorf("invalid property list: %w", err)
+			}
+			valueStart, err := nextXmlPlistElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeXmlPlistValue(decoder, valueStart)
+			if err != nil {
+				return nil, err
+			}
+			dict.set(key, value)
+		}
+	case "array":
+		array := []interface{}{}
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("invalid property list: %w", err)
+			}
+			if _, ok := token.(xml.EndElement); ok {
+				return array, nil
+			}
+			if elementStart, ok := token.(xml.StartElement); ok {
+				value, err := decodeXmlPlistValue(decoder, elementStart)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+		}
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, fmt.Errorf("invalid property list: %w", err)
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := decoder.DecodeElement(&text, &start); err != nil {
+		return nil, fmt.Errorf("invalid property list: %w", err)
+	}
+	switch start.Name.Local {
+	case "string":
+		return text, nil
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "data":
+		return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	case "date":
+		return time.Parse(time.RFC3339, strings.TrimSpace(text))
+	}
+	return nil, fmt.Errorf("invalid property list: unsupported element <%v>", start.Name.Local)
+}
+
+var plistXmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// encodeXmlPlist writes the property list in the format that Xcode uses.
+func encodeXmlPlist(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	buffer.WriteString("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	buffer.WriteString("<plist version=\"1.0\">\n")
+	if err := writeXmlPlistValue(&buffer, value, ""); err != nil {
+		return nil, err
+	}
+	buffer.WriteString("</plist>\n")
+	return buffer.Bytes(), nil
+}
+
+func writeXmlPlistValue(buffer *bytes.Buffer, value interface{}, indentation string) error {
+	switch typedValue := value.(type) {
+	case *plistDict:
+		if len(typedValue.keys) == 0 {
+			buffer.WriteString(indentation + "<dict/>\n")
+			return nil
+		}
+		buffer.WriteString(indentation + "<dict>\n")
+		for _, key := range typedValue.keys {
+			buffer.WriteString(indentation + "\t<key>" + plistXmlEscaper.Replace(key) + "</key>\n")
+			if err := writeXmlPlistValue(buffer, typedValue.values[key], indentation+"\t"); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(indentation + "</dict>\n")
+	case []interface{}:
+		if len(typedValue) == 0 {
+			buffer.WriteString(indentation + "<array/>\n")
+			return nil
+		}
+		buffer.WriteString(indentation + "<array>\n")
+		for _, element := range typedValue {
+			if err := writeXmlPlistValue(buffer, element, indentation+"\t"); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(indentation + "</array>\n")
+	case string:
+		buffer.WriteString(indentation + "<string>" + plistXmlEscaper.Replace(typedValue) + "</string>\n")
+	case int64:
+		buffer.WriteString(indentation + "<integer>" + strconv.FormatInt(typedValue, 10) + "</integer>\n")
+	case float64:
+		buffer.WriteString(indentation + "<real>" + strconv.FormatFloat(typedValue, 'g', -1, 64) + "</real>\n")
+	case bool:
+		if typedValue {
+			buffer.WriteString(indentation + "<true/>\n")
+		} else {
+			buffer.WriteString(indentation + "<false/>\n")
+		}
+	case []byte:
+		buffer.WriteString(indentation + "<data>" + base64.StdEncoding.EncodeToString(typedValue) + "</data>\n")
+	case time.Time:
+		buffer.WriteString(indentation + "<date>" + typedValue.UTC().Format(time.RFC3339) + "</date>\n")
+	default:
+		return fmt.Errorf("cannot write property list value of type %T", value)
+	}
+	return nil
+}
+
+// Binary property lists
+
+type binaryPlistDecoder struct {
+	contents      []byte
+	offsets       []uint64
+	objectRefSize int
+	depth         int
+}
+
+func decodeBinaryPlist(contents []byte) (interface{}, error) {
+	if len(contents) < len(binaryPlistHeader)+32 {
+		return nil, errors.New("invalid binary property list: too short")
+	}
+	trailer := contents[len(contents)-32:]
+	offsetIntSize := int(trailer[6])
+	objectRefSize := int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:16])
+	topObject := binary.BigEndian.Uint64(trailer[16:24])
+	offsetTableOffset := binary.BigEndian.Uint64(trailer[24:32])
+
+	if offsetIntSize == 0 || objectRefSize == 0 || numObjects == 0 || topObject >= numObjects ||
+		offsetTableOffset+numObjects*uint64(offsetIntSize) > uint64(len(contents)-32) {
+		return nil, errors.New("invalid binary property list: invalid trailer")
+	}
+
+	decoder := &binaryPlistDecoder{contents: contents, objectRefSize: objectRefSize}
+	for i := uint64(0); i < numObjects; i++ {
+		start := offsetTableOffset + i*uint64(offsetIntSize)
+		decoder.offsets = append(decoder.offsets, readBigEndianUint(contents[start:start+uint64(offsetIntSize)]))
+	}
+	return decoder.decodeObject(topObject)
+}
+
+func readBigEndianUint(data []byte) (value uint64) {
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return
+}
+
+func (decoder *binaryPlistDecoder) bytesAt(offset uint64, length uint64) ([]byte, error) {
+	if offset+length > uint64(len(decoder.contents)) || offset+length < offset {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return decoder.contents[offset : offset+length], nil
+}
+
+// lengthAt reads the length of the object with the marker at the offset, it returns the offset at which the contents of the object start.
+func (decoder *binaryPlistDecoder) lengthAt(offset uint64) (length uint64, contentOffset uint64, err error) {
+	marker := decoder.contents[offset]
+	length = uint64(marker & 0x0F)
+	contentOffset = offset + 1
+	if length != 0x0F {
+		return
+	}
+
+	intMarker, err := decoder.bytesAt(contentOffset, 1)
+	if err != nil || intMarker[0]&0xF0 != 0x10 {
+		return 0, 0, errors.New("invalid binary property list: invalid object length")
+	}
+	intSize := uint64(1) << (intMarker[0] & 0x0F)
+	data, err := decoder.bytesAt(contentOffset+1, intSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	return readBigEndianUint(data), contentOffset + 1 + intSize, nil
+}
+
+func (decoder *binaryPlistDecoder) objectRefs(offset uint64, count uint64) ([]uint64, error) {
+	data, err := decoder.bytesAt(offset, count*uint64(decoder.objectRefSize))
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]uint64, count)
+	for i := range refs {
+		refs[i] = readBigEndianUint(data[i*decoder.objectRefSize : (i+1)*decoder.objectRefSize])
+	}
+	return refs, nil
+}
+
+func (decoder *binaryPlistDecoder) decodeObject(ref uint64) (interface{}, error) {
+	if ref >= uint64(len(decoder.offsets)) || decoder.offsets[ref] >= uint64(len(decoder.contents)) {
+		return nil, errors.New("invalid binary property list: invalid object reference")
+	}
+	// property lists cannot contain cycles, a very deep nesting means that the references are corrupt
+	if decoder.depth > 512 {
+		return nil, errors.New("invalid binary property list: nested too deeply")
+	}
+	decoder.depth++
+	defer func() { decoder.depth-- }()
+
+	offset := decoder.offsets[ref]
+	marker := decoder.contents[offset]
+	switch marker & 0xF0 {
+	case 0x00:
+		switch marker {
+		case 0x08:
+			return false, nil
+		case 0x09:
+			return true, nil
+		}
+	case 0x10:
+		data, err := decoder.bytesAt(offset+1, uint64(1)<<(marker&0x0F))
+		if err != nil {
+			return nil, err
+		}
+		return int64(readBigEndianUint(data)), nil
+	case 0x20:
+		data, err := decoder.bytesAt(offset+1, uint64(1)<<(marker&0x0F))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 4 {
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+		}
+		return math.Float64frombits(readBigEndianUint(data)), nil
+	case 0x30:
+		data, err := decoder.bytesAt(offset+1, 8)
+		if err != nil {
+			return nil, err
+		}
+		seconds := math.Float64frombits(binary.BigEndian.Uint64(data))
+		return plistReferenceDate.Add(time.Duration(seconds * float64(time.Second))), nil
+	case 0x40, 0x50, 0x60:
+		length, contentOffset, err := decoder.lengthAt(offset)
+		if err != nil {
+			return nil, err
+		}
+		if marker&0xF0 == 0x60 {
+			data, err := decoder.bytesAt(contentOffset, length*2)
+			if err != nil {
+				return nil, err
+			}
+			units := make([]uint16, length)
+			for i := range units {
+				units[i] = binary.BigEndian.Uint16(data[i*2:])
+			}
+			return string(utf16.Decode(units)), nil
+		}
+		data, err := decoder.bytesAt(contentOffset, length)
+		if err != nil {
+			return nil, err
+		}
+		if marker&0xF0 == 0x40 {
+			return append([]byte{}, data...), nil
+		}
+		return string(data), nil
+	case 0xA0:
+		length, contentOffset, err := decoder.lengthAt(offset)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := decoder.objectRefs(contentOffset, length)
+		if err != nil {
+			return nil, err
+		}
+		array := make([]interface{}, 0, length)
+		for _, elementRef := range refs {
+			element, err := decoder.decodeObject(elementRef)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, element)
+		}
+		return array, nil
+	case 0xD0:
+		length, contentOffset, err := decoder.lengthAt(offset)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := decoder.objectRefs(contentOffset, length*2)
+		if err != nil {
+			return nil, err
+		}
+		dict := newPlistDict()
+		for i := uint64(0); i < length; i++ {
+			key, err := decoder.decodeObject(refs[i])
+			if err != nil {
+				return nil, err
+			}
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, errors.New("invalid binary property list: dictionary key is not a string")
+			}
+			value, err := decoder.decodeObject(refs[length+i])
+			if err != nil {
+				return nil, err
+			}
+			dict.set(keyString, value)
+		}
+		return dict, nil
+	}
+	return nil, fmt.Errorf("invalid binary property list: unsupported object type 0x%02x", marker)
+}
+
+type binaryPlistEncoder struct {
+	objects [][]byte
+	// the references of container objects are filled in once all objects are known, since the reference size depends on the object count
+	containers map[int][]int
+}
+
+func encodeBinaryPlist(value interface{}) ([]byte, error) {
+	encoder := &binaryPlistEncoder{containers: make(map[int][]int)}
+	if _, err := encoder.flatten(value); err != nil {
+		return nil, err
+	}
+
+	objectRefSize := byteSizeFor(uint64(len(encoder.objects)))
+	var buffer bytes.Buffer
+	buffer.WriteString(binaryPlistHeader)
+	offsets := make([]uint64, len(encoder.objects))
+	for index, object := range encoder.objects {
+		offsets[index] = uint64(buffer.Len())
+		buffer.Write(object)
+		for _, ref := range encoder.containers[index] {
+			buffer.Write(bigEndianUint(uint64(ref), objectRefSize))
+		}
+	}
+
+	offsetTableOffset := uint64(buffer.Len())
+	offsetIntSize := byteSizeFor(offsetTableOffset)
+	for _, offset := range offsets {
+		buffer.Write(bigEndianUint(offset, offsetIntSize))
+	}
+
+	trailer := make([]byte, 32)
+	trailer[6] = byte(offsetIntSize)
+	trailer[7] = byte(objectRefSize)
+	binary.BigEndian.PutUint64(trailer[8:], uint64(len(encoder.objects)))
+	binary.BigEndian.PutUint64(trailer[16:], 0)
+	binary.BigEndian.PutUint64(trailer[24:], offsetTableOffset)
+	buffer.Write(trailer)
+	return buffer.Bytes(), nil
+}
+
+func byteSizeFor(maxValue uint64) int {
+	switch {
+	case maxValue < 1<<8:
+		return 1
+	case maxValue < 1<<16:
+		return 2
+	case maxValue < 1<<32:
+		return 4
+	}
+	return 8
+}
+
+func bigEndianUint(value uint64, size int) []byte {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, value)
+	return data[8-size:]
+}
+
+// flatten adds the value and all values it contains to the object table and returns the reference of the value.
+func (encoder *binaryPlistEncoder) flatten(value interface{}) (int, error) {
+	index := len(encoder.objects)
+	encoder.objects = append(encoder.objects, nil)
+
+	var object []byte
+	switch typedValue := value.(type) {
+	case *plistDict:
+		object = binaryPlistMarker(0xD0, uint64(len(typedValue.keys)))
+		var keyRefs, valueRefs []int
+		for _, key := range typedValue.keys {
+			keyRef, _ := encoder.flatten(key)
+			keyRefs = append(keyRefs, keyRef)
+		}
+		for _, key := range typedValue.keys {
+			valueRef, err := encoder.flatten(typedValue.values[key])
+			if err != nil {
+				return 0, err
+			}
+			valueRefs = append(valueRefs, valueRef)
+		}
+		encoder.containers[index] = append(keyRefs, valueRefs...)
+	case []interface{}:
+		object = binaryPlistMarker(0xA0, uint64(len(typedValue)))
+		var refs []int
+		for _, element := range typedValue {
+			ref, err := encoder.flatten(element)
+			if err != nil {
+				return 0, err
+			}
+			refs = append(refs, ref)
+		}
+		encoder.containers[index] = refs
+	case string:
+		object = encodeBinaryPlistString(typedValue)
+	case int64:
+		object = encodeBinaryPlistInt(typedValue)
+	case float64:
+		object = append([]byte{0x23}, bigEndianUint(math.Float64bits(typedValue), 8)...)
+	case bool:
+		object = []byte{0x08}
+		if typedValue {
+			object = []byte{0x09}
+		}
+	case []byte:
+		object = append(binaryPlistMarker(0x40, uint64(len(typedValue))), typedValue...)
+	case time.Time:
+		seconds := typedValue.Sub(plistReferenceDate).Seconds()
+		object = append([]byte{0x33}, bigEndianUint(math.Float64bits(seconds), 8)...)
+	default:
+		return 0, fmt.Errorf("cannot write property list value of type %T", value)
+	}
+
+	encoder.objects[index] = object
+	return index, nil
+}
+
+func binaryPlistMarker(objectType byte, length uint64) []byte {
+	if length < 0x0F {
+		return []byte{objectType | byte(length)}
+	}
+	return append([]byte{objectType | 0x0F}, encodeBinaryPlistInt(int64(length))...)
+}
+
+func encodeBinaryPlistInt(value int64) []byte {
+	if value < 0 {
+		return append([]byte{0x13}, bigEndianUint(uint64(value), 8)...)
+	}
+	size := byteSizeFor(uint64(value))
+	power := map[int]byte{1: 0, 2: 1, 4: 2, 8: 3}[size]
+	return append([]byte{0x10 | power}, bigEndianUint(uint64(value), size)...)
+}
+
+func encodeBinaryPlistString(value string) []byte {
+	for _, char := range value {
+		if char > 0x7F {
+			units := utf16.Encode([]rune(value))
+			object := binaryPlistMarker(0x60, uint64(len(units)))
+			for _, unit := range units {
+				object = append(object, byte(unit>>8), byte(unit))
+			}
+			return object
+		}
+	}
+	return append(binaryPlistMarker(0x50, uint64(len(value))), value...)
+}
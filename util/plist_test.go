@@ -0,0 +1,113 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+const exampleXmlPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleName</key>
+	<string>Example &amp; Co</string>
+	<key>LSRequiresIPhoneOS</key>
+	<true/>
+	<key>UIDeviceFamily</key>
+	<array>
+		<integer>1</integer>
+		<integer>2</integer>
+	</array>
+	<key>UIBackgroundModes</key>
+	<array/>
+	<key>Ratio</key>
+	<real>1.5</real>
+	<key>Icon</key>
+	<data>AQID</data>
+	<key>Created</key>
+	<date>2020-01-02T03:04:05Z</date>
+	<key>UIApplicationSceneManifest</key>
+	<dict/>
+</dict>
+</plist>
+`
+
+// generated with Python's plistlib: {'CFBundleName': 'Example', 'CFBundleVersion': '1', 'LSRequiresIPhoneOS': True, 'UIDeviceFamily': [1, 2],
+// 'Größe': 'ünïcode', 'Count': 300}
+const exampleBinaryPlist = "62706c6973743030d60102030405060708090a0d0e5c434642756e646c654e616d655f100f434642756e646c6556657273696f6e5f10124c53526571756972657349" +
+	"50686f6e654f535e554944657669636546616d696c79650047007200f600df006555436f756e74574578616d706c65513109a20b0c100110026700fc006e00ef0063006f00" +
+	"64006511012c081522344958636971737477797b8a0000000000000101000000000000000f0000000000000000000000000000008d"
+
+func TestXmlPlistRoundTrip(t *testing.T) {
+	value, format, err := decodePlist([]byte(exampleXmlPlist))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format != plistFormatXml {
+		t.Errorf("Incorrect result, expected the XML format but was '%v'", format)
+	}
+
+	result, err := encodePlist(value, format)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(result) != exampleXmlPlist {
+		t.Errorf("Incorrect result, got:\n%v", string(result))
+	}
+}
+
+func TestBinaryPlistRoundTrip(t *testing.T) {
+	contents, _ := hex.DecodeString(exampleBinaryPlist)
+	value, format, err := decodePlist(contents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if format != plistFormatBinary {
+		t.Errorf("Incorrect result, expected the binary format but was '%v'", format)
+	}
+
+	dict := value.(*plistDict)
+	expectedKeys := []string{"CFBundleName", "CFBundleVersion", "LSRequiresIPhoneOS", "UIDeviceFamily", "Größe", "Count"}
+	if !reflect.DeepEqual(dict.keys, expectedKeys) {
+		t.Errorf("Incorrect result, keys were %v", dict.keys)
+	}
+	if dict.values["Größe"] != "ünïcode" || dict.values["Count"] != int64(300) || dict.values["LSRequiresIPhoneOS"] != true ||
+		!reflect.DeepEqual(dict.values["UIDeviceFamily"], []interface{}{int64(1), int64(2)}) {
+		t.Errorf("Incorrect result, values were %v", dict.values)
+	}
+
+	encoded, err := encodePlist(value, format)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded, _, err := decodePlist(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Errorf("Incorrect result, expected %v but was %v", value, decoded)
+	}
+}
+
+func TestDecodeInvalidPlist(t *testing.T) {
+	for _, contents := range []string{"", "bplist00", "<plist><dict><string>no key</string></dict></plist>", "<html></html>"} {
+		if _, _, err := decodePlist([]byte(contents)); err == nil {
+			t.Errorf("Incorrect result, expected an error for '%v'", contents)
+		}
+	}
+}
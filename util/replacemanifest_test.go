@@ -17,6 +17,7 @@ package util
 import (
   "testing"
   "net/url"
+  "strings"
 )
 
 // example AndroidManifest generated by Onegini Cordova Plugin 4.2+
@@ -103,7 +104,7 @@ const manifestWithoutOneginiIntent string = `<?xml version='1.0' encoding='utf-8
 // WHEN ANDROID MANIFEST DOESN'T CONTAIN ONEGINI INTENT FILTER
 //////////////////////////////////////////////////////////////
 
-// should not modify manifests when there are no Onegini IntentFilters found inside
+// should not modify manifests without a MainActivity or when there is no Onegini IntentFilter to remove
 func TestReplaceManifestNotModifyManifest(t *testing.T) {
   testSuit := []struct {
 		manifest string
@@ -112,7 +113,6 @@ func TestReplaceManifestNotModifyManifest(t *testing.T) {
 	}{
 		{"", false, ""},
     {"", true, ""},
-		{manifestWithoutOneginiIntent, false, manifestWithoutOneginiIntent},
     {manifestWithoutOneginiIntent, true, manifestWithoutOneginiIntent},
 	}
   url, _ := url.Parse("onegini://loginsuccess")
@@ -125,6 +125,16 @@ func TestReplaceManifestNotModifyManifest(t *testing.T) {
 	}
 }
 
+// should add the Onegini IntentFilter to the MainActivity when it is missing
+func TestReplaceManifestAddCustomIntentFilter(t *testing.T) {
+  url, _ := url.Parse("cordovaexample://login-success")
+
+  result := ReplaceManifest(manifestWithoutOneginiIntent, false, url)
+  if result != manifestWithOneginiIntentFilter {
+      t.Errorf("Incorrect result, the manifest should contain the Onegini IntentFilter:\n%v", result)
+  }
+}
+
 ////////////////////////////////////////////////////////////
 // WHEN ANDROID MANIFEST CONTAINS OLD ONEGINI INTENT FILTER
 ////////////////////////////////////////////////////////////
@@ -222,7 +232,7 @@ func TestReplaceManifestReplaceCustomIntentFilterWithHttpIntentFilter(t *testing
                 <action android:name="android.intent.action.MAIN" />
                 <category android:name="android.intent.category.LAUNCHER" />
             </intent-filter>
-            <intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent">
+            <intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent" android:autoVerify="true">
                 <action android:name="android.intent.action.VIEW" />
                 <category android:name="android.intent.category.DEFAULT" />
                 <category android:name="android.intent.category.BROWSABLE" />
@@ -296,7 +306,7 @@ func TestReplaceManifestReplaceHttpsIntentFilterWithHttpIntentFilter(t *testing.
                 <action android:name="android.intent.action.MAIN" />
                 <category android:name="android.intent.category.LAUNCHER" />
             </intent-filter>
-            <intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent">
+            <intent-filter android:label="OneginiRedirectionIntent" android:name="OneginiRedirectionIntent" android:autoVerify="true">
                 <action android:name="android.intent.action.VIEW" />
                 <category android:name="android.intent.category.DEFAULT" />
                 <category android:name="android.intent.category.BROWSABLE" />
@@ -313,3 +323,51 @@ func TestReplaceManifestReplaceHttpsIntentFilterWithHttpIntentFilter(t *testing.
       t.Errorf("Incorrect result, the manifest should contain new scheme 'https://onegini.com/loginsuccess':\n%v", result)
   }
 }
+
+// should remove autoVerify when an App Link intent filter is replaced with a custom scheme
+func TestReplaceManifestReplaceAppLinkIntentFilterWithCustomIntent(t *testing.T) {
+  httpsUrl, _ := url.Parse("https://www.onegini.com/loginsuccess")
+  customUrl, _ := url.Parse("onegini://loginsuccess")
+
+  appLinkManifest := ReplaceManifest(manifestWithOneginiIntentFilter, false, httpsUrl)
+  result := ReplaceManifest(appLinkManifest, false, customUrl)
+  if result != ReplaceManifest(manifestWithOneginiIntentFilter, false, customUrl) {
+      t.Errorf("Incorrect result, the intent filter should not contain autoVerify for a custom scheme:\n%v", result)
+  }
+
+  result2 := ReplaceManifest(appLinkManifest, true, customUrl)
+  if result2 != manifestWithoutOneginiIntent {
+      t.Errorf("Incorrect result, the manifest should not contain Onegini IntentFilter:\n%v", result2)
+  }
+}
+
+// should repair an Onegini IntentFilter with a missing action or category
+func TestReplaceManifestRepairIntentFilter(t *testing.T) {
+  url, _ := url.Parse("cordovaexample://login-success")
+  manifestWithIncompleteIntentFilter := strings.Replace(manifestWithOneginiIntentFilter,
+    "                <category android:name=\"android.intent.category.BROWSABLE\" />\n", "", 1)
+
+  result := ReplaceManifest(manifestWithIncompleteIntentFilter, false, url)
+  if result != manifestWithOneginiIntentFilter {
+      t.Errorf("Incorrect result, the Onegini IntentFilter should contain the BROWSABLE category:\n%v", result)
+  }
+}
+
+// should update the Cordova and Capacitor manifests in the same way as the manifests of native apps
+func TestReplaceManifestMatchesUpdateManifestRedirectIntentFilter(t *testing.T) {
+  manifests := []string{manifestWithOneginiIntentFilter, manifestWithHttpsOneginiIntentFilter, manifestWithoutOneginiIntent}
+  redirectUrls := []string{"onegini://loginsuccess", "https://www.onegini.com/loginsuccess"}
+
+  for _, manifest := range manifests {
+    for _, redirectUrl := range redirectUrls {
+      url, _ := url.Parse(redirectUrl)
+      expected, err := UpdateManifestRedirectIntentFilter(manifest, "MainActivity", url)
+      if err != nil {
+        t.Fatalf("Unexpected error: %v", err)
+      }
+      if result := ReplaceManifest(manifest, false, url); result != expected {
+        t.Errorf("Incorrect result, the manifest should be the same as for a native app for '%v':\n%v", redirectUrl, result)
+      }
+    }
+  }
+}
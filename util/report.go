@@ -0,0 +1,99 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Report is the machine readable summary of a configuration run that is printed with --output json.
+type Report struct {
+	Platform                string                   `json:"platform"`
+	Flavor                  string                   `json:"flavor,omitempty"`
+	BuildType               string                   `json:"build_type,omitempty"`
+	DryRun                  bool                     `json:"dry_run"`
+	Options                 *options                 `json:"options"`
+	FilesWritten            []string                 `json:"files_written"`
+	FilesDeleted            []string                 `json:"files_deleted"`
+	KeystoreHash            string                   `json:"keystore_hash,omitempty"`
+	CertificateFingerprints []CertificateFingerprint `json:"certificate_fingerprints"`
+	AssetLinks              *AssetLinks              `json:"asset_links,omitempty"`
+	Hints                   []Hint                   `json:"hints"`
+	Warnings                []string                 `json:"warnings"`
+}
+
+// FlavorsReport is the machine readable summary of a run that configures several flavors at once, it contains the report of every flavor.
+type FlavorsReport struct {
+	Platform string    `json:"platform"`
+	DryRun   bool      `json:"dry_run"`
+	Flavors  []*Report `json:"flavors"`
+}
+
+type CertificateFingerprint struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+func NewReport(config *Config, platform string, hints []Hint, dryRun bool) (*Report, error) {
+	report := &Report{
+		Platform:                platform,
+		Flavor:                  config.FlavorName,
+		BuildType:               config.BuildType,
+		DryRun:                  dryRun,
+		Options:                 config.Options,
+		FilesWritten:            []string{},
+		FilesDeleted:            []string{},
+		KeystoreHash:            config.keystoreHash,
+		CertificateFingerprints: []CertificateFingerprint{},
+		AssetLinks:              config.assetLinks,
+		Hints:                   append([]Hint{}, hints...),
+		Warnings:                append([]string{}, config.Warnings...),
+	}
+
+	for _, change := range config.Changes() {
+		// the change set may be shared with the configs of other flavors
+		if !config.changedFiles[change.Path] {
+			continue
+		}
+		switch change.Kind {
+		case ChangeCreate, ChangeModify:
+			report.FilesWritten = append(report.FilesWritten, change.Path)
+		case ChangeDelete:
+			report.FilesDeleted = append(report.FilesDeleted, change.Path)
+		}
+	}
+
+	for _, certName := range sortedKeys(config.Certs) {
+		cert, err := parseCertificate(certName, config.Certs[certName])
+		if err != nil {
+			return nil, err
+		}
+		report.CertificateFingerprints = append(report.CertificateFingerprints, CertificateFingerprint{Name: certName, SHA256: certificateFingerprint(cert)})
+	}
+
+	return report, nil
+}
+
+// PrintJson prints the report, or any other document, as indented JSON to stdout.
+func PrintJson(document interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("could not write the JSON output: %w", err)
+	}
+	return nil
+}
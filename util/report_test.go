@@ -0,0 +1,107 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewReport(t *testing.T) {
+	appDir := t.TempDir()
+	writtenPath := filepath.Join(appDir, "written.txt")
+	deletedPath := filepath.Join(appDir, "deleted.txt")
+	_ = os.WriteFile(deletedPath, []byte("delete me\n"), 0644)
+
+	config := &Config{Options: &options{AppID: "ExampleApp"}, FlavorName: "dev"}
+	config.writeFile(writtenPath, []byte("written\n"))
+	config.removeFile(deletedPath)
+	config.Warnings = []string{"a warning"}
+
+	report, err := NewReport(config, "android", []Hint{{Message: "a hint"}}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Platform != "android" || report.Flavor != "dev" || !report.DryRun || report.Options.AppID != "ExampleApp" {
+		t.Errorf("Incorrect result, unexpected report: %+v", report)
+	}
+	if len(report.FilesWritten) != 1 || report.FilesWritten[0] != writtenPath {
+		t.Errorf("Incorrect result, files written: %v", report.FilesWritten)
+	}
+	if len(report.FilesDeleted) != 1 || report.FilesDeleted[0] != deletedPath {
+		t.Errorf("Incorrect result, files deleted: %v", report.FilesDeleted)
+	}
+	if len(report.Hints) != 1 || len(report.Warnings) != 1 {
+		t.Errorf("Incorrect result, hints: %v, warnings: %v", report.Hints, report.Warnings)
+	}
+
+	config.Certs = map[string]string{"invalid.cer": "not a certificate"}
+	if _, err := NewReport(config, "android", nil, true); !errors.Is(err, ErrInvalidCertificate) {
+		t.Errorf("Incorrect result, expected an invalid certificate error but was: %v", err)
+	}
+}
+
+func TestNewReportWithSharedChanges(t *testing.T) {
+	appDir := t.TempDir()
+	devPath := filepath.Join(appDir, "dev", "written.txt")
+	prodPath := filepath.Join(appDir, "prod", "written.txt")
+
+	devConfig := &Config{Options: &options{AppID: "DevApp"}, FlavorName: "dev"}
+	prodConfig := &Config{Options: &options{AppID: "ProdApp"}, FlavorName: "prod"}
+	prodConfig.ShareChanges(devConfig)
+	devConfig.writeFile(devPath, []byte("dev\n"))
+	prodConfig.writeFile(prodPath, []byte("prod\n"))
+
+	if len(devConfig.Changes()) != 2 || len(prodConfig.Changes()) != 2 {
+		t.Errorf("Incorrect result, expected both configs to share the changes: %v, %v", devConfig.Changes(), prodConfig.Changes())
+	}
+
+	devReport, err := NewReport(devConfig, "android", nil, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	prodReport, err := NewReport(prodConfig, "android", nil, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(devReport.FilesWritten) != 1 || devReport.FilesWritten[0] != devPath {
+		t.Errorf("Incorrect result, files written for dev: %v", devReport.FilesWritten)
+	}
+	if len(prodReport.FilesWritten) != 1 || prodReport.FilesWritten[0] != prodPath {
+		t.Errorf("Incorrect result, files written for prod: %v", prodReport.FilesWritten)
+	}
+}
+
+func TestAndroidManifestUpdateHints(t *testing.T) {
+	testCases := []struct {
+		description string
+		config      *Config
+		hints       int
+	}{
+		{"native project", &Config{}, 1},
+		{"native project with --update-manifest", &Config{UpdateManifest: true}, 0},
+		{"NativeScript project", &Config{ConfigureForNativeScript: true}, 1},
+		{"Cordova project", &Config{ConfigureForCordova: true}, 0},
+		{"Capacitor project", &Config{ConfigureForCapacitor: true}, 0},
+	}
+	for _, testCase := range testCases {
+		testCase.config.Options = &options{RedirectUrl: "myapp://loginsuccess"}
+		if hints := AndroidManifestUpdateHints(testCase.config); len(hints) != testCase.hints {
+			t.Errorf("Incorrect number of hints for a %v, expected %v but was %v", testCase.description, testCase.hints, len(hints))
+		}
+	}
+}
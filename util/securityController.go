@@ -1,28 +1,34 @@
 package util
 
 import (
-	"os"
 	"path"
 	"strings"
 )
 
 func RemoveAndroidSecurityController(config *Config) {
-	storePath := config.getAndroidSecurityControllerPath()
-	_ = os.Remove(storePath)
+	deleteFileIfExists(config, config.getAndroidSecurityControllerPath())
 }
 
-func RemoveIOSSecurityController(config *Config) {
+func RemoveIOSSecurityController(config *Config) error {
 	group := "Configuration"
-	xcodeProjPath := config.getIosXcodeProjPath()
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
+	}
 	configModelPath := config.getIosConfigModelPath()
 	headerStorePath := path.Join(configModelPath, "SecurityController.h")
 	modelStorePath := path.Join(configModelPath, "SecurityController.m")
 
-	removeFileFromXcodeProj(headerStorePath, xcodeProjPath, group, config.FlavorName)
-	removeFileFromXcodeProj(modelStorePath, xcodeProjPath, group, config.FlavorName)
-	_ = os.Remove(headerStorePath)
-	_ = os.Remove(modelStorePath)
+	if err := removeFileFromXcodeProj(config, headerStorePath, xcodeProjPath, group, config.FlavorName); err != nil {
+		return err
+	}
+	if err := removeFileFromXcodeProj(config, modelStorePath, xcodeProjPath, group, config.FlavorName); err != nil {
+		return err
+	}
+	deleteFileIfExists(config, headerStorePath)
+	deleteFileIfExists(config, modelStorePath)
 
+	return nil
 }
 
 func (config *Config) getAndroidSecurityControllerPath() string {
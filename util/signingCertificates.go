@@ -0,0 +1,280 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	keystore "github.com/pavlo-v-chernykh/keystore-go/v4"
+	"github.com/smallstep/pkcs7"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	jksMagic = 0xfeedfeed
+
+	// the APK Signing Block is stored right before the ZIP central directory, see
+	// https://source.android.com/docs/security/features/apksigning/v2#apk-signing-block
+	apkSigningBlockMagic         = "APK Sig Block 42"
+	apkSignatureSchemeV2BlockId  = 0x7109871a
+	apkSignatureSchemeV3BlockId  = 0xf05368c0
+	apkSignatureSchemeV31BlockId = 0x1b93ad61
+	zipEndOfCentralDirectorySize = 22
+)
+
+// a SHA-256 fingerprint as printed by keytool and apksigner, either with or without colons
+var sha256FingerprintRegexp = regexp.MustCompile(`^(?:[0-9A-Fa-f]{2}:){31}[0-9A-Fa-f]{2}$|^[0-9A-Fa-f]{64}$`)
+
+// readSigningCertificateFingerprints returns the SHA-256 fingerprints of the certificates that the app is signed with. The certificate is
+// given as a PEM or DER encoded certificate, a JKS or PKCS12 keystore, a signed APK or directly as its SHA-256 fingerprint. The password is used
+// to read a keystore, the alias selects the key of a JKS keystore with several keys.
+func readSigningCertificateFingerprints(cert string, password string, alias string) ([]string, error) {
+	if sha256FingerprintRegexp.MatchString(cert) && !exists(cert) {
+		return []string{formatFingerprint(cert)}, nil
+	}
+
+	contents, err := os.ReadFile(cert)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSigningCertificate, err)
+	}
+	signingCerts, err := parseSigningCertificates(contents, password, alias)
+	if err != nil {
+		return nil, fmt.Errorf("%w from '%v': %v", ErrInvalidSigningCertificate, cert, err)
+	}
+
+	var fingerprints []string
+	for _, signingCert := range signingCerts {
+		if fingerprint := certificateFingerprint(signingCert); !containsString(fingerprints, fingerprint) {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+	return fingerprints, nil
+}
+
+func parseSigningCertificates(contents []byte, password string, alias string) ([]*x509.Certificate, error) {
+	if block, _ := pem.Decode(contents); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unsupported PEM block '%v', expected a CERTIFICATE", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		return []*x509.Certificate{cert}, err
+	}
+	if cert, err := x509.ParseCertificate(contents); err == nil {
+		return []*x509.Certificate{cert}, nil
+	}
+
+	switch {
+	case len(contents) >= 4 && binary.BigEndian.Uint32(contents) == jksMagic:
+		cert, err := readJksCertificate(contents, password, alias)
+		if err != nil {
+			return nil, err
+		}
+		return []*x509.Certificate{cert}, nil
+	case bytes.HasPrefix(contents, []byte("PK\x03\x04")):
+		return readApkCertificates(contents)
+	}
+	_, cert, _, err := pkcs12.DecodeChain(contents, password)
+	if err != nil {
+		return nil, fmt.Errorf("expected a certificate, a keystore or an APK: %v", err)
+	}
+	return []*x509.Certificate{cert}, nil
+}
+
+// readJksCertificate returns the certificate of the key with the alias, the alias can be left out when the keystore contains a single key.
+func readJksCertificate(contents []byte, password string, alias string) (*x509.Certificate, error) {
+	jks := keystore.New()
+	if err := jks.Load(bytes.NewReader(contents), []byte(password)); err != nil {
+		return nil, fmt.Errorf("cannot read the JKS keystore, check the keystore password: %v", err)
+	}
+
+	var keyAliases []string
+	for _, entryAlias := range jks.Aliases() {
+		if jks.IsPrivateKeyEntry(entryAlias) {
+			keyAliases = append(keyAliases, entryAlias)
+		}
+	}
+	sort.Strings(keyAliases)
+	if len(alias) == 0 {
+		if len(keyAliases) != 1 {
+			return nil, fmt.Errorf("the JKS keystore contains %v keys, select the signing key by its alias: %v", len(keyAliases),
+				strings.Join(keyAliases, ", "))
+		}
+		alias = keyAliases[0]
+	}
+
+	chain, err := jks.GetPrivateKeyEntryCertificateChain(alias)
+	if err != nil || len(chain) == 0 {
+		return nil, fmt.Errorf("the JKS keystore does not contain a key with the alias '%v', use one of: %v", alias, strings.Join(keyAliases, ", "))
+	}
+	return x509.ParseCertificate(chain[0].Content)
+}
+
+// readApkCertificates returns the signing certificates of the APK from the newest APK Signature Scheme that it is signed with, or from the JAR
+// signature (v1 scheme) when the APK does not have an APK Signing Block.
+func readApkCertificates(contents []byte) ([]*x509.Certificate, error) {
+	signingBlock, err := apkSigningBlock(contents)
+	if err != nil {
+		return nil, err
+	}
+	for _, blockId := range []uint32{apkSignatureSchemeV31BlockId, apkSignatureSchemeV3BlockId, apkSignatureSchemeV2BlockId} {
+		if signers, ok := signingBlock[blockId]; ok {
+			return readApkSignerCertificates(signers)
+		}
+	}
+	return readApkJarSignatureCertificates(contents)
+}
+
+// apkSigningBlock returns the values in the APK Signing Block by their ID, it is empty when the APK does not have a signing block.
+func apkSigningBlock(contents []byte) (map[uint32][]byte, error) {
+	values := make(map[uint32][]byte)
+	searchStart := len(contents) - zipEndOfCentralDirectorySize - 0xffff
+	if searchStart < 0 {
+		searchStart = 0
+	}
+	endOfCentralDirectory := bytes.LastIndex(contents[searchStart:], []byte("PK\x05\x06"))
+	if endOfCentralDirectory < 0 || searchStart+endOfCentralDirectory+zipEndOfCentralDirectorySize > len(contents) {
+		return nil, errors.New("the APK is not a valid ZIP file")
+	}
+	centralDirectoryOffset := int(binary.LittleEndian.Uint32(contents[searchStart+endOfCentralDirectory+16:]))
+	if centralDirectoryOffset < 24 || centralDirectoryOffset > len(contents) ||
+		string(contents[centralDirectoryOffset-16:centralDirectoryOffset]) != apkSigningBlockMagic {
+		return values, nil
+	}
+
+	blockSize := binary.LittleEndian.Uint64(contents[centralDirectoryOffset-24:])
+	blockStart := centralDirectoryOffset - 8 - int(blockSize)
+	if blockSize > uint64(centralDirectoryOffset) || blockStart < 0 || binary.LittleEndian.Uint64(contents[blockStart:]) != blockSize {
+		return nil, errors.New("the APK Signing Block of the APK is invalid")
+	}
+
+	pairs := contents[blockStart+8 : centralDirectoryOffset-24]
+	for len(pairs) > 0 {
+		if len(pairs) < 12 {
+			return nil, errors.New("the APK Signing Block of the APK is invalid")
+		}
+		pairSize := binary.LittleEndian.Uint64(pairs)
+		if pairSize < 4 || pairSize > uint64(len(pairs)-8) {
+			return nil, errors.New("the APK Signing Block of the APK is invalid")
+		}
+		values[binary.LittleEndian.Uint32(pairs[8:])] = pairs[12 : 8+pairSize]
+		pairs = pairs[8+pairSize:]
+	}
+	return values, nil
+}
+
+// readApkSignerCertificates returns the first certificate of every signer in the value of an APK Signature Scheme v2 or v3 block. A signer
+// starts with its signed data, which starts with the digests followed by the certificates.
+func readApkSignerCertificates(value []byte) ([]*x509.Certificate, error) {
+	signers, _, err := readLengthPrefixed(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for len(signers) > 0 {
+		var signer, signedData, certificates, certificate []byte
+		if signer, signers, err = readLengthPrefixed(signers); err != nil {
+			return nil, err
+		}
+		if signedData, _, err = readLengthPrefixed(signer); err != nil {
+			return nil, err
+		}
+		if _, signedData, err = readLengthPrefixed(signedData); err != nil {
+			return nil, err
+		}
+		if certificates, _, err = readLengthPrefixed(signedData); err != nil {
+			return nil, err
+		}
+		if certificate, _, err = readLengthPrefixed(certificates); err != nil {
+			return nil, err
+		}
+		cert, err := x509.ParseCertificate(certificate)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the signing certificate of the APK: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("the APK Signing Block of the APK does not contain any signers")
+	}
+	return certs, nil
+}
+
+func readLengthPrefixed(data []byte) (value []byte, rest []byte, err error) {
+	if len(data) < 4 || uint64(binary.LittleEndian.Uint32(data)) > uint64(len(data)-4) {
+		return nil, nil, errors.New("the APK Signing Block of the APK is invalid")
+	}
+	size := binary.LittleEndian.Uint32(data)
+	return data[4 : 4+size], data[4+size:], nil
+}
+
+// readApkJarSignatureCertificates returns the signers of the PKCS#7 signature files in the META-INF directory of an APK that is signed with
+// the JAR signing scheme (v1).
+func readApkJarSignatureCertificates(contents []byte) ([]*x509.Certificate, error) {
+	apk, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("the APK is not a valid ZIP file: %v", err)
+	}
+
+	var certs []*x509.Certificate
+	for _, file := range apk.File {
+		if path.Dir(file.Name) != "META-INF" || !containsString([]string{".RSA", ".DSA", ".EC"}, strings.ToUpper(path.Ext(file.Name))) {
+			continue
+		}
+		signatureFile, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read '%v' of the APK: %v", file.Name, err)
+		}
+		signature := new(bytes.Buffer)
+		_, err = signature.ReadFrom(signatureFile)
+		signatureFile.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cannot read '%v' of the APK: %v", file.Name, err)
+		}
+
+		signedData, err := pkcs7.Parse(signature.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("cannot read '%v' of the APK: %v", file.Name, err)
+		}
+		if signer := signedData.GetOnlySigner(); signer != nil {
+			certs = append(certs, signer)
+		}
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("the APK is not signed")
+	}
+	return certs, nil
+}
+
+// formatFingerprint formats a fingerprint the same way as certificateFingerprint, in uppercase with colons between the bytes
+func formatFingerprint(fingerprint string) string {
+	hexDigits := strings.ToUpper(strings.ReplaceAll(fingerprint, ":", ""))
+	hexBytes := make([]string, 0, len(hexDigits)/2)
+	for i := 0; i < len(hexDigits); i += 2 {
+		hexBytes = append(hexBytes, hexDigits[i:i+2])
+	}
+	return strings.Join(hexBytes, ":")
+}
@@ -0,0 +1,160 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// the fingerprint of testdata/release.pem, which is the certificate in the keystores and the signed APKs in testdata
+const releaseCertFingerprint = "B4:3E:96:10:86:0D:26:98:EA:6E:0C:26:B9:74:CF:31:85:97:39:6A:2F:AE:74:CA:9D:45:E5:28:D2:0D:89:C7"
+
+func writeTestFile(t *testing.T, name string, contents []byte) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filePath, contents, 0644); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return filePath
+}
+
+func TestReadSigningCertificateFingerprintFromCertificate(t *testing.T) {
+	contents, err := os.ReadFile(filepath.Join("testdata", "release.pem"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	block, _ := pem.Decode(contents)
+	derPath := writeTestFile(t, "release.der", block.Bytes)
+
+	for _, certPath := range []string{filepath.Join("testdata", "release.pem"), derPath} {
+		fingerprints, err := readSigningCertificateFingerprints(certPath, "", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fingerprints) != 1 || fingerprints[0] != releaseCertFingerprint {
+			t.Errorf("Incorrect result, expected the fingerprint of the release certificate for %v but got %v", certPath, fingerprints)
+		}
+	}
+}
+
+func TestReadSigningCertificateFingerprintFromPkcs12Keystore(t *testing.T) {
+	for _, keystoreName := range []string{"release.p12", "release-legacy.p12"} {
+		fingerprints, err := readSigningCertificateFingerprints(filepath.Join("testdata", keystoreName), "secret", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fingerprints) != 1 || fingerprints[0] != releaseCertFingerprint {
+			t.Errorf("Incorrect result, expected the fingerprint of the release certificate in %v but got %v", keystoreName, fingerprints)
+		}
+
+		if _, err := readSigningCertificateFingerprints(filepath.Join("testdata", keystoreName), "incorrect", ""); !errors.Is(err, ErrInvalidSigningCertificate) {
+			t.Errorf("Incorrect result, expected ErrInvalidSigningCertificate for an incorrect password but got %v", err)
+		}
+	}
+}
+
+func TestReadSigningCertificateFingerprintFromFingerprint(t *testing.T) {
+	for _, value := range []string{releaseCertFingerprint, strings.ToLower(releaseCertFingerprint), strings.ReplaceAll(releaseCertFingerprint, ":", "")} {
+		fingerprints, err := readSigningCertificateFingerprints(value, "", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(fingerprints) != 1 || fingerprints[0] != releaseCertFingerprint {
+			t.Errorf("Incorrect result, expected %v but got %v", releaseCertFingerprint, fingerprints)
+		}
+	}
+}
+
+// testdata/release.jks contains the release key and an upload key, its password and the passwords of the keys are "secret"
+func TestReadSigningCertificateFingerprintFromJksKeystore(t *testing.T) {
+	keystorePath := filepath.Join("testdata", "release.jks")
+	fingerprints, err := readSigningCertificateFingerprints(keystorePath, "secret", "release")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fingerprints) != 1 || fingerprints[0] != releaseCertFingerprint {
+		t.Errorf("Incorrect result, expected the fingerprint of the release certificate but got %v", fingerprints)
+	}
+
+	if fingerprints, err := readSigningCertificateFingerprints(keystorePath, "secret", "upload"); err != nil || fingerprints[0] == releaseCertFingerprint {
+		t.Errorf("Incorrect result, expected the fingerprint of the upload certificate but got %v, %v", fingerprints, err)
+	}
+
+	testCases := []struct {
+		password string
+		alias    string
+		message  string
+	}{
+		{"secret", "", "release, upload"},
+		{"secret", "unknown", "release, upload"},
+		{"incorrect", "release", "password"},
+	}
+	for _, testCase := range testCases {
+		_, err := readSigningCertificateFingerprints(keystorePath, testCase.password, testCase.alias)
+		if !errors.Is(err, ErrInvalidSigningCertificate) || !strings.Contains(err.Error(), testCase.message) {
+			t.Errorf("Incorrect result for the alias '%v', expected ErrInvalidSigningCertificate mentioning '%v' but got %v", testCase.alias,
+				testCase.message, err)
+		}
+	}
+}
+
+// the APKs in testdata are signed with the release key: with the JAR signature only (v1), with APK Signature Scheme v2 only and with the v1, v2
+// and v3 schemes together
+func TestReadSigningCertificateFingerprintFromApk(t *testing.T) {
+	for _, apkName := range []string{"app-release-v1.apk", "app-release-v2.apk", "app-release.apk"} {
+		fingerprints, err := readSigningCertificateFingerprints(filepath.Join("testdata", apkName), "", "")
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", apkName, err)
+		}
+		if len(fingerprints) != 1 || fingerprints[0] != releaseCertFingerprint {
+			t.Errorf("Incorrect result, expected the fingerprint of the release certificate for %v but got %v", apkName, fingerprints)
+		}
+	}
+}
+
+func TestReadSigningCertificateFingerprintInvalidFile(t *testing.T) {
+	unsignedApk := new(bytes.Buffer)
+	apkWriter := zip.NewWriter(unsignedApk)
+	manifestWriter, _ := apkWriter.Create("AndroidManifest.xml")
+	_, _ = manifestWriter.Write([]byte("<manifest />"))
+	_ = apkWriter.Close()
+
+	testCases := []struct {
+		name     string
+		contents []byte
+		message  string
+	}{
+		{"app-unsigned.apk", unsignedApk.Bytes(), "not signed"},
+		{"app-truncated.apk", []byte("PK\x03\x04"), "not a valid ZIP file"},
+		{"release.txt", []byte("not a certificate"), "expected a certificate, a keystore or an APK"},
+	}
+	for _, testCase := range testCases {
+		certPath := writeTestFile(t, testCase.name, testCase.contents)
+		_, err := readSigningCertificateFingerprints(certPath, "", "")
+		if !errors.Is(err, ErrInvalidSigningCertificate) || !strings.Contains(err.Error(), testCase.message) {
+			t.Errorf("Incorrect result for %v, expected ErrInvalidSigningCertificate mentioning '%v' but got %v", testCase.name, testCase.message, err)
+		}
+	}
+
+	if _, err := readSigningCertificateFingerprints("B4:3E:96", "", ""); !errors.Is(err, ErrInvalidSigningCertificate) {
+		t.Errorf("Incorrect result, expected ErrInvalidSigningCertificate for an incomplete fingerprint but got %v", err)
+	}
+}
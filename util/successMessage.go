@@ -21,8 +21,12 @@ import (
 
 func PrintSuccessMessage(config *Config) {
 	fmt.Print("SUCCESS! Your application ")
-	if len(config.FlavorName) > 0 {
+	if len(config.FlavorName) > 0 && len(config.BuildType) > 0 {
+		fmt.Printf("(\"%v\" flavor, \"%v\" build type) ", config.FlavorName, config.BuildType)
+	} else if len(config.FlavorName) > 0 {
 		fmt.Printf("(\"%v\" flavor) ", config.FlavorName)
+	} else if len(config.BuildType) > 0 {
+		fmt.Printf("(\"%v\" build type) ", config.BuildType)
 	}
 	fmt.Print("is now configured.\n\n")
 
@@ -45,36 +49,62 @@ func PrintSuccessMessage(config *Config) {
 	}
 }
 
+// Hint describes a manual step that is still required after configuring the application.
+type Hint struct {
+	Message  string `json:"message"`
+	Scheme   string `json:"scheme,omitempty"`
+	MoreInfo string `json:"more_info,omitempty"`
+}
+
 func PrintAndroidManifestUpdateHint(config *Config) {
-	if config.ConfigureForCordova {
-		return
+	PrintHints(AndroidManifestUpdateHints(config))
+}
+
+func PrintIosInfoPlistUpdateHint(config *Config) {
+	PrintHints(IosInfoPlistUpdateHints(config))
+}
+
+func PrintHints(hints []Hint) {
+	for _, hint := range hints {
+		fmt.Println("")
+		fmt.Println("INFO: " + hint.Message)
+		if len(hint.Scheme) > 0 {
+			fmt.Println("INFO: The scheme that you must add: " + hint.Scheme)
+		}
+		if len(hint.MoreInfo) > 0 {
+			fmt.Println("INFO: More info is provided here: " + hint.MoreInfo)
+		}
+	}
+}
+
+func AndroidManifestUpdateHints(config *Config) []Hint {
+	if config.ConfigureForCordova || config.ConfigureForCapacitor || config.updatesNativeAndroidManifest() {
+		return nil
+	}
+
+	hint := Hint{
+		Message:  "Don't forget to update your android manifest to let Android handle the custom URL scheme",
+		Scheme:   strings.Split(config.Options.RedirectUrl, "://")[0],
+		MoreInfo: "https://docs.onegini.com/public/android-sdk/topics/authenticate-user-with-pin.html#handling-the-authentication-callback-during-registration",
 	}
 	if config.ConfigureForNativeScript {
-		fmt.Println("")
-		fmt.Println("INFO: Don't forget to update your android manifest to let Android handle the custom URL scheme")
-		fmt.Println("INFO: The scheme that you must add: " + strings.Split(config.Options.RedirectUrl, "://")[0])
-		fmt.Println("INFO: More info is provided here: https://docs.onegini.com/public/nativescript-plugin/topics/configuration.html#configuring-a-custom-url-scheme-for-authentication")
-	} else {
-		fmt.Println("")
-		fmt.Println("INFO: Don't forget to update your android manifest to let Android handle the custom URL scheme")
-		fmt.Println("INFO: The scheme that you must add: " + strings.Split(config.Options.RedirectUrl, "://")[0])
-		fmt.Println("INFO: More info is provided here: https://docs.onegini.com/public/android-sdk/topics/authenticate-user-with-pin.html#handling-the-authentication-callback-during-registration")
+		hint.MoreInfo = "https://docs.onegini.com/public/nativescript-plugin/topics/configuration.html#configuring-a-custom-url-scheme-for-authentication"
 	}
+	return []Hint{hint}
 }
 
-func PrintIosInfoPlistUpdateHint(config *Config) {
-	if config.ConfigureForCordova {
-		return
+func IosInfoPlistUpdateHints(config *Config) []Hint {
+	if config.ConfigureForCordova || config.UpdateInfoPlist {
+		return nil
+	}
+
+	hint := Hint{
+		Message:  "If you are using the system browser for user registration, don't forget to update your Info.plist to let iOS handle the custom URL scheme",
+		Scheme:   strings.Split(config.Options.RedirectUrl, "://")[0],
+		MoreInfo: "https://docs.onegini.com/public/ios-sdk/topics/user-authentication.html#handling-registration-request-url-with-external-web-browser",
 	}
 	if config.ConfigureForNativeScript {
-		fmt.Println("")
-		fmt.Println("INFO: If you are using the system browser for user registration, don't forget to update your Info.plist to let iOS handle the custom URL scheme")
-		fmt.Println("INFO: The scheme that you must add: " + strings.Split(config.Options.RedirectUrl, "://")[0])
-		fmt.Println("INFO: More info is provided here: https://docs.onegini.com/public/nativescript-plugin/topics/configuration.html#configuring-a-custom-url-scheme-for-authentication")
-	} else {
-		fmt.Println("")
-		fmt.Println("INFO: If you are using the system browser for user registration, don't forget to update your Info.plist to let iOS handle the custom URL scheme")
-		fmt.Println("INFO: The scheme that you must add: " + strings.Split(config.Options.RedirectUrl, "://")[0])
-		fmt.Println("INFO: More info is provided here: https://docs.onegini.com/public/ios-sdk/topics/user-authentication.html#handling-registration-request-url-with-external-web-browser")
+		hint.MoreInfo = "https://docs.onegini.com/public/nativescript-plugin/topics/configuration.html#configuring-a-custom-url-scheme-for-authentication"
 	}
+	return []Hint{hint}
 }
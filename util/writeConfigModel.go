@@ -14,219 +14,162 @@
 
 package util
 
-import (
-	"io/ioutil"
-	"os"
-	"regexp"
-	"strings"
+import "fmt"
 
-	"github.com/onewelcome/sdk-configurator/version"
-
-	"fmt"
+func WriteIOSConfigModel(config *Config, generateSwiftConfigModel bool) error {
+	if err := cleanupOldIosConfigModel(config, generateSwiftConfigModel); err != nil {
+		return err
+	}
 
-	"github.com/onewelcome/sdk-configurator/data"
-)
+	modelData, err := newIosConfigModelData(config)
+	if err != nil {
+		return err
+	}
 
-func WriteIOSConfigModel(config *Config) {
-	cleanupOldIosConfigModel(config)
+	if generateSwiftConfigModel {
+		modelSwiftFile, err := renderIosConfigModel(config, config.getIosConfigModelPathSwiftFile(), "OneginiConfigModel.swift", modelData)
+		if err != nil {
+			return err
+		}
+		return WriteIosSwiftConfigModel(modelSwiftFile, config)
+	}
 
-	modelMFile := overrideIosConfigModelValues(config)
-	modelHFile := readIosConfigModelFromAssetsOrProject(config.getIosConfigModelPathHFile(), "lib/OneginiConfigModel.h")
+	modelMFile, err := renderIosConfigModel(config, config.getIosConfigModelPathMFile(), "OneginiConfigModel.m", modelData)
+	if err != nil {
+		return err
+	}
+	modelHFile, err := renderIosConfigModel(config, config.getIosConfigModelPathHFile(), "OneginiConfigModel.h", modelData)
+	if err != nil {
+		return err
+	}
 
-	WriteIosConfigModel(modelMFile, modelHFile, config)
+	return WriteIosConfigModel(modelMFile, modelHFile, config)
 }
-func WriteIosConfigModel(modelMFile []byte, modelHFile []byte, config *Config) {
-	xcodeProjPath := config.getIosXcodeProjPath()
-	modelMFilePath := config.getIosConfigModelPathMFile()
-	modelHFilePath := config.getIosConfigModelPathHFile()
 
-	ioutil.WriteFile(modelMFilePath, modelMFile, os.ModePerm)
-	ioutil.WriteFile(modelHFilePath, modelHFile, os.ModePerm)
+// renderIosConfigModel renders the config model and merges it into the existing config model in the project, so that only the generated
+// regions are replaced and code that was added to the config model is preserved.
+func renderIosConfigModel(config *Config, modelPath string, templateName string, modelData configModelData) ([]byte, error) {
+	model, err := renderConfigModel(config, templateName, modelData)
+	if err != nil {
+		return nil, err
+	}
+	if !config.fileExists(modelPath) {
+		return model, nil
+	}
 
-	iosAddConfigModelFileToXcodeProj(modelMFilePath, xcodeProjPath, config.AppTarget, config.FlavorName)
-	iosAddConfigModelFileToXcodeProj(modelHFilePath, xcodeProjPath, config.AppTarget, config.FlavorName)
+	existingModel, err := config.readFile(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Config model in Project: %w", err)
+	}
+	mergedModel, merged, err := mergeGeneratedRegions(existingModel, model)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update the existing config model '%v': %w", modelPath, err)
+	}
+	if !merged {
+		config.AddWarning(fmt.Sprintf("The existing config model '%v' does not contain any generated region markers, it is replaced completely", modelPath))
+		return model, nil
+	}
+	return mergedModel, nil
 }
 
-func cleanupOldIosConfigModel(config *Config) {
+func WriteIosConfigModel(modelMFile []byte, modelHFile []byte, config *Config) error {
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
+	}
 	modelMFilePath := config.getIosConfigModelPathMFile()
 	modelHFilePath := config.getIosConfigModelPathHFile()
 
-	deleteFileIfExists(modelMFilePath, "ERROR: Could not delete old config model M file in Project")
-	deleteFileIfExists(modelHFilePath, "ERROR: Could not delete old config model H file in Project")
+	config.writeFile(modelMFilePath, modelMFile)
+	config.writeFile(modelHFilePath, modelHFile)
 
-	iosRemoveConfigModelFileFromXcodeProj(modelMFilePath, config.getIosXcodeProjPath(), config.FlavorName)
-	iosRemoveConfigModelFileFromXcodeProj(modelHFilePath, config.getIosXcodeProjPath(), config.FlavorName)
+	if err := iosAddConfigModelFileToXcodeProj(config, modelMFilePath, xcodeProjPath, config.AppTarget, config.FlavorName); err != nil {
+		return err
+	}
+	return iosAddConfigModelFileToXcodeProj(config, modelHFilePath, xcodeProjPath, config.AppTarget, config.FlavorName)
 }
 
-func readIosConfigModelFromAssetsOrProject(modelPath string, assetPath string) []byte {
-	_, errFileNotFoundInAppProject := os.Stat(modelPath)
-	if errFileNotFoundInAppProject == nil {
-		appProjectModel, err := ioutil.ReadFile(modelPath)
-		if err != nil {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: could not read Config model in Project: %v\n", err.Error()))
-			os.Exit(1)
-		}
-		return appProjectModel
-	} else {
-		modelFromTmp, errFileNotFoundInTmp := data.Asset(assetPath)
-		if errFileNotFoundInTmp != nil {
-			os.Stderr.WriteString(fmt.Sprintf("ERROR: could not read Config model in assets: %v\n", errFileNotFoundInTmp))
-			os.Exit(1)
-		}
-
-		return modelFromTmp
+func WriteIosSwiftConfigModel(modelSwiftFile []byte, config *Config) error {
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
 	}
-}
+	modelSwiftFilePath := config.getIosConfigModelPathSwiftFile()
 
-func overrideIosConfigModelValues(config *Config) (modelMFile []byte) {
-	modelMFile = readIosConfigModelFromAssetsOrProject(config.getIosConfigModelPathMFile(), "lib/OneginiConfigModel.m")
+	config.writeFile(modelSwiftFilePath, modelSwiftFile)
 
-	base64Certs := getBase64Certs(config)
+	return iosAddConfigModelFileToXcodeProj(config, modelSwiftFilePath, xcodeProjPath, config.AppTarget, config.FlavorName)
+}
 
-	configMap := map[string]string{
-		"ONGServerType":      config.Options.ServerType,
-		"ONGServerVersion":   config.Options.ServerVersion,
-		"ONGAppIdentifier":   config.Options.AppID,
-		"ONGAppVersion":      config.Options.AppVersion,
-		"ONGAppBaseURL":      config.Options.TokenServerUri,
-		"ONGResourceBaseURL": config.Options.ResourceGatewayUris[0],
-		"ONGRedirectURL":     config.Options.RedirectUrl,
+// cleanupOldIosConfigModel removes the config model in the language that is not generated, so switching languages does not leave the other one
+// behind. The config model in the generated language is kept, it is updated in place.
+func cleanupOldIosConfigModel(config *Config, generateSwiftConfigModel bool) error {
+	xcodeProjPath, err := config.getIosXcodeProjPath()
+	if err != nil {
+		return err
 	}
 
-	for preference, value := range configMap {
-		newPref := `@"` + preference + `" : @"` + value + `"`
-		re := regexp.MustCompile(`@"` + preference + `"\s*:\s*@".*"`)
-		modelMFile = re.ReplaceAll(modelMFile, []byte(newPref))
+	oldModelFilePaths := []string{config.getIosConfigModelPathSwiftFile()}
+	if generateSwiftConfigModel {
+		oldModelFilePaths = []string{config.getIosConfigModelPathMFile(), config.getIosConfigModelPathHFile()}
 	}
+	for _, modelFilePath := range oldModelFilePaths {
+		deleteFileIfExists(config, modelFilePath)
 
-	newDef := "certificates\n{\n	return @[@\"" + strings.Join(base64Certs, "\", @\"") + "\"]; //Base64Certificates"
-
-	re := regexp.MustCompile(`certificates\s*{\s*return @\[.*\];.*`)
-	modelMFile = re.ReplaceAll(modelMFile, []byte(newDef))
-
-	serverPublicKeyNewDef := "serverPublicKey\n{\n	return @\"" + config.Options.ServerPublicKey.Encoded + "\";"
-	reServerPublicKey := regexp.MustCompile(`serverPublicKey\s*{\s*return @\".*\";`)
-	modelMFile = reServerPublicKey.ReplaceAll(modelMFile, []byte(serverPublicKeyNewDef))
+		if err := iosRemoveConfigModelFileFromXcodeProj(config, modelFilePath, xcodeProjPath, config.FlavorName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	versionRe := regexp.MustCompile(`CONFIGURATOR_VERSION`)
-	modelMFile = versionRe.ReplaceAll(modelMFile, []byte(version.Version))
+func newIosConfigModelData(config *Config) (configModelData, error) {
+	base64Certs, err := getBase64Certs(config)
+	if err != nil {
+		return configModelData{}, err
+	}
 
-	return
+	modelData := newConfigModelData(config)
+	modelData.Certificates = base64Certs
+	return modelData, nil
 }
 
-func WriteAndroidConfigModel(config *Config, generateJavaConfigModel bool) {
+func WriteAndroidConfigModel(config *Config, generateJavaConfigModel bool) error {
 	modelJavaPath := config.getAndroidConfigModelJavaPath()
 	modelKotlinPath := config.getAndroidConfigModelKotlinPath()
 	keyStorePath := config.getAndroidKeystorePath()
 
-	deleteFileIfExists(modelJavaPath, "ERROR: Could not delete old java config model in Project")
-	deleteFileIfExists(modelKotlinPath, "ERROR: Could not delete old kotlin config model in Project")
+	deleteFileIfExists(config, modelJavaPath)
+	deleteFileIfExists(config, modelKotlinPath)
+	// a Kotlin config model that is left in the java directory would be a duplicate of the one in the Kotlin source directory
+	deleteFileIfExists(config, config.getAndroidConfigModelKotlinPathInJavaDir())
 
-	if generateJavaConfigModel {
-		model := readAndroidJavaConfigModelFromAssets()
-		model = overrideAndroidConfigJavaModelValues(config, keyStorePath, model)
-		os.WriteFile(modelJavaPath, model, os.ModePerm)
-	} else {
-		model := readAndroidKotlinConfigModelFromAssets()
-		model = overrideAndroidConfigKotlinModelValues(config, keyStorePath, model)
-		os.WriteFile(modelKotlinPath, model, os.ModePerm)
+	keystoreHash, err := config.calculateKeystoreHash(keyStorePath)
+	if err != nil {
+		return err
 	}
-}
-
-func deleteFileIfExists(filePath string, errorDescription string) {
-	fmt.Sprintf("%v, %v", 1, 2)
-	if exists(filePath) {
-		err := os.Remove(filePath)
+	modelData := newConfigModelData(config)
+	modelData.PackageName = getPackageIdentifierFromConfig(config)
+	modelData.KeystoreHash = keystoreHash
 
+	if generateJavaConfigModel {
+		model, err := renderConfigModel(config, "OneginiConfigModel.java", modelData)
 		if err != nil {
-			os.Stderr.WriteString(fmt.Sprintf("%v: %v\n", errorDescription, err.Error()))
-			os.Exit(1)
+			return err
 		}
-	}
-}
-
-func readAndroidKotlinConfigModelFromAssets() []byte {
-	model, errFileNotFoundInTmp := data.Asset("lib/OneginiConfigModel.kt")
-	if errFileNotFoundInTmp != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not read config model in assets: %v\n", errFileNotFoundInTmp))
-		os.Exit(1)
-	}
-
-	return model
-}
-
-func readAndroidJavaConfigModelFromAssets() []byte {
-	model, errFileNotFoundInTmp := data.Asset("lib/OneginiConfigModel.java")
-	if errFileNotFoundInTmp != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not read config model in assets: %v\n", errFileNotFoundInTmp))
-		os.Exit(1)
-	}
-
-	return model
-}
-
-func overrideAndroidConfigKotlinModelValues(config *Config, keystorePath string, model []byte) []byte {
-	stringConfigMap := map[string]string{
-		"appIdentifier":   config.Options.AppID,
-		"redirectUri":     config.Options.RedirectUrl,
-		"appVersion":      config.Options.AppVersion,
-		"baseUrl":         config.Options.TokenServerUri,
-		"resourceBaseUrl": config.Options.ResourceGatewayUris[0],
-		"serverPublicKey": config.Options.ServerPublicKey.Encoded,
-		"keyStoreHash":    CalculateKeystoreHash(keystorePath),
-		"serverType":      config.Options.ServerType,
-		"serverVersion":   config.Options.ServerVersion,
-	}
-
-	newPackage := "package " + getPackageIdentifierFromConfig(config)
-	packageRe := regexp.MustCompile(`package\s.*`)
-	model = packageRe.ReplaceAll(model, []byte(newPackage))
-
-	for preference, value := range stringConfigMap {
-		newPref := preference + ` = "` + value + `"`
-		if preference == "serverPublicKey" && len(value) == 0 {
-			newPref = preference + ` = null;`
+		config.writeFile(modelJavaPath, model)
+	} else {
+		model, err := renderConfigModel(config, "OneginiConfigModel.kt", modelData)
+		if err != nil {
+			return err
 		}
-
-		re := regexp.MustCompile(preference + `\s=\s.*`)
-		model = re.ReplaceAll(model, []byte(newPref))
+		config.writeFile(modelKotlinPath, model)
 	}
-
-	re := regexp.MustCompile(`CONFIGURATOR_VERSION`)
-	model = re.ReplaceAll(model, []byte(version.Version))
-
-	return model
+	return nil
 }
 
-func overrideAndroidConfigJavaModelValues(config *Config, keystorePath string, model []byte) []byte {
-	stringConfigMap := map[string]string{
-		"appIdentifier":   config.Options.AppID,
-		"redirectionUri":  config.Options.RedirectUrl,
-		"appVersion":      config.Options.AppVersion,
-		"baseURL":         config.Options.TokenServerUri,
-		"resourceBaseURL": config.Options.ResourceGatewayUris[0],
-		"serverPublicKey": config.Options.ServerPublicKey.Encoded,
-		"keystoreHash":    CalculateKeystoreHash(keystorePath),
-		"serverType":      config.Options.ServerType,
-		"serverVersion":   config.Options.ServerVersion,
-	}
-
-	newPackage := "package " + getPackageIdentifierFromConfig(config) + ";"
-	packageRe := regexp.MustCompile(`package\s.*;`)
-	model = packageRe.ReplaceAll(model, []byte(newPackage))
-
-	for preference, value := range stringConfigMap {
-		newPref := preference + ` = "` + value + `";`
-		if preference == "serverPublicKey" && len(value) == 0 {
-			newPref = preference + ` = null;`
-		}
-
-		re := regexp.MustCompile(preference + `\s=\s.*;`)
-		model = re.ReplaceAll(model, []byte(newPref))
+func deleteFileIfExists(config *Config, filePath string) {
+	if config.fileExists(filePath) {
+		config.removeFile(filePath)
 	}
-
-	re := regexp.MustCompile(`CONFIGURATOR_VERSION`)
-	model = re.ReplaceAll(model, []byte(version.Version))
-
-	return model
 }
@@ -15,117 +15,381 @@
 package util
 
 import (
-	"os"
 	"path"
+	"path/filepath"
 
 	"fmt"
 
-	"os/exec"
-
 	"strings"
-
-	"github.com/onewelcome/sdk-configurator/data"
 )
 
+type pbxProject struct {
+	path    string
+	root    map[string]interface{}
+	objects map[string]interface{}
+}
+
 var (
-	removeFileScriptPath string
-	addFileScriptPath    string
+	pbxSourceFileExtensions = map[string]string{
+		".m":     "sourcecode.c.objc",
+		".mm":    "sourcecode.cpp.objcpp",
+		".c":     "sourcecode.c.c",
+		".cpp":   "sourcecode.cpp.cpp",
+		".swift": "sourcecode.swift",
+	}
+	pbxHeaderFileExtensions = map[string]string{
+		".h":   "sourcecode.c.h",
+		".hpp": "sourcecode.cpp.h",
+	}
+	pbxResourceFileExtensions = map[string]string{
+		".plist": "text.plist.xml",
+		".json":  "text.json",
+		".cer":   "file",
+	}
 )
 
-func init() {
-	tempPath := path.Join(os.TempDir(), "sdk-configurator")
-	removeFileScriptPath = path.Join(tempPath, "lib", "removeFileFromXcodeProject.rb")
-	addFileScriptPath = path.Join(tempPath, "lib", "addFileToXcodeProject.rb")
+func iosRemoveCertFilesFromXcodeProj(config *Config, certPath string, xcodeProjPath string) error {
+	return removeFileFromXcodeProj(config, certPath, xcodeProjPath, "Resources", "")
+}
 
-	if err := data.RestoreAsset(tempPath, "lib/removeFileFromXcodeProject.rb"); err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not restore required asset: %v\n", err))
-		os.Exit(1)
+func iosAddConfigModelFileToXcodeProj(config *Config, modelFile string, xcodeProjPath string, appTarget string, subfolder string) error {
+	return addFileToXcodeProj(config, modelFile, xcodeProjPath, appTarget, "Configuration", subfolder)
+}
+
+func iosRemoveConfigModelFileFromXcodeProj(config *Config, modelFile string, xcodeProjPath string, subfolder string) error {
+	return removeFileFromXcodeProj(config, modelFile, xcodeProjPath, "Configuration", subfolder)
+}
+
+func removeFileFromXcodeProj(config *Config, filePath string, xcodeProjPath string, group string, subfolder string) error {
+	project, err := loadXcodeProj(config, xcodeProjPath)
+	if err != nil {
+		return err
+	}
+
+	groupId := project.childGroup(project.mainGroup(), group)
+	if groupId == "" {
+		return nil
+	}
+	parentGroupId := groupId
+	if subfolder != "" {
+		groupId = project.childGroup(groupId, subfolder)
+		if groupId == "" {
+			return nil
+		}
 	}
 
-	if err := data.RestoreAsset(tempPath, "lib/addFileToXcodeProject.rb"); err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not restore required asset: %v\n", err))
-		os.Exit(1)
+	groupName := path.Join(group, subfolder)
+	if !project.removeFile(groupId, filePath) {
+		return nil
 	}
+	config.recordXcodeEdit(fmt.Sprintf("remove file '%v' from group '%v'", path.Base(filePath), groupName))
+	if subfolder != "" && len(arrayValue(project.object(groupId), "children")) == 0 {
+		project.removeGroup(parentGroupId, groupId)
+		config.recordXcodeEdit(fmt.Sprintf("remove empty group '%v'", groupName))
+	}
+
+	saveXcodeProj(config, project)
+	return nil
 }
 
-func iosRemoveCertFilesFromXcodeProj(certPath string, xcodeProjPath string) {
-	removeFileFromXcodeProj(certPath, xcodeProjPath, "Resources", "")
+func addFileToXcodeProj(config *Config, filePath string, xcodeProjPath string, appName string, group string, subfolder string) error {
+	project, err := loadXcodeProj(config, xcodeProjPath)
+	if err != nil {
+		return err
+	}
+
+	groupId := project.childGroup(project.mainGroup(), group)
+	if groupId == "" {
+		groupId = project.addGroup(project.mainGroup(), group)
+		config.recordXcodeEdit(fmt.Sprintf("add group '%v'", group))
+	}
+	if subfolder != "" {
+		subgroupId := project.childGroup(groupId, subfolder)
+		if subgroupId == "" {
+			subgroupId = project.addGroup(groupId, subfolder)
+			config.recordXcodeEdit(fmt.Sprintf("add group '%v'", path.Join(group, subfolder)))
+		}
+		groupId = subgroupId
+	}
+
+	fileId := project.findFile(groupId, filePath)
+	if fileId == "" {
+		fileId = project.addFile(groupId, filePath)
+		config.recordXcodeEdit(fmt.Sprintf("add file '%v' to group '%v'", path.Base(filePath), path.Join(group, subfolder)))
+		for _, targetId := range project.targets(appName) {
+			phaseType := project.addFileToTarget(targetId, fileId)
+			config.recordXcodeEdit(fmt.Sprintf("add file '%v' to the %v build phase of target '%v'", path.Base(filePath), pbxDefaultPhaseNames[phaseType], appName))
+		}
+	}
+
+	saveXcodeProj(config, project)
+	return nil
 }
 
-func iosAddConfigModelFileToXcodeProj(modelFile string, xcodeProjPath string, appTarget string, subfolder string) {
-	addFileToXcodeProj(modelFile, xcodeProjPath, appTarget, "Configuration", subfolder)
+func loadXcodeProj(config *Config, xcodeProjPath string) (*pbxProject, error) {
+	contents, err := config.readFile(path.Join(xcodeProjPath, "project.pbxproj"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read Xcode project: %w", err)
+	}
+
+	project, err := parsePbxProject(xcodeProjPath, contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Xcode project: %w", err)
+	}
+	return project, nil
 }
 
-func iosRemoveConfigModelFileFromXcodeProj(modelFile string, xcodeProjPath string, subfolder string) {
-	removeFileFromXcodeProj(modelFile, xcodeProjPath, "Configuration", subfolder)
+func saveXcodeProj(config *Config, project *pbxProject) {
+	config.writeFile(project.pbxprojPath(), project.serialize())
 }
 
-func removeFileFromXcodeProj(filepath string, xcodeProjPath string, group string, subfolder string) {
-	ruby := checkForRuby()
-	checkForXcodeprojGem()
+func parsePbxProject(xcodeProjPath string, contents []byte) (project *pbxProject, err error) {
+	project = &pbxProject{path: xcodeProjPath}
+	project.root, err = parsePbxproj(contents)
+	if err != nil {
+		return nil, err
+	}
+	project.objects, _ = project.root["objects"].(map[string]interface{})
+	if project.objects == nil || project.rootObject() == nil {
+		return nil, fmt.Errorf("'%v' does not contain a valid project", project.pbxprojPath())
+	}
 
-	cmd := exec.Command(
-		ruby,
-		removeFileScriptPath,
-		xcodeProjPath,
-		filepath,
-		group,
-		subfolder,
-	)
+	return project, nil
+}
 
-	startCmd(cmd)
+func (project *pbxProject) pbxprojPath() string {
+	return path.Join(project.path, "project.pbxproj")
 }
 
-func addFileToXcodeProj(filePath string, xcodeProjPath string, appName string, group string, subfolder string) {
-	ruby := checkForRuby()
-	checkForXcodeprojGem()
+func (project *pbxProject) object(id string) map[string]interface{} {
+	object, _ := project.objects[id].(map[string]interface{})
+	return object
+}
 
-	cmd := exec.Command(
-		ruby,
-		addFileScriptPath,
-		xcodeProjPath,
-		filePath,
-		appName,
-		group,
-		subfolder,
-	)
+func (project *pbxProject) rootObject() map[string]interface{} {
+	id, _ := project.root["rootObject"].(string)
+	return project.object(id)
+}
 
-	startCmd(cmd)
+func (project *pbxProject) mainGroup() string {
+	return stringValue(project.rootObject(), "mainGroup")
 }
 
-func checkForRuby() (ruby string) {
-	ruby, lookErr := exec.LookPath("ruby")
-	if lookErr != nil {
-		os.Stderr.WriteString(fmt.Sprintln("ERROR: Could not find ruby executable in $PATH"))
-		os.Exit(1)
+func (project *pbxProject) targets(name string) (targetIds []string) {
+	for _, targetId := range arrayValue(project.rootObject(), "targets") {
+		if stringValue(project.object(targetId), "name") == name {
+			targetIds = append(targetIds, targetId)
+		}
 	}
+	return
+}
 
-	return ruby
+// childGroup returns the identifier of the direct subgroup with the given name or path, or an empty string when there is none.
+func (project *pbxProject) childGroup(parentId string, name string) string {
+	for _, childId := range arrayValue(project.object(parentId), "children") {
+		child := project.object(childId)
+		if stringValue(child, "isa") != "PBXGroup" {
+			continue
+		}
+		if stringValue(child, "name") == name || (stringValue(child, "name") == "" && stringValue(child, "path") == name) {
+			return childId
+		}
+	}
+	return ""
 }
 
-func checkForXcodeprojGem() {
-	cmd := exec.Command("gem", "list")
+func (project *pbxProject) addGroup(parentId string, name string) string {
+	groupId := generatePbxObjectId(project.objects)
+	project.objects[groupId] = map[string]interface{}{
+		"isa":        "PBXGroup",
+		"children":   []interface{}{},
+		"name":       name,
+		"sourceTree": "<group>",
+	}
+	project.appendToArray(parentId, "children", groupId)
+
+	return groupId
+}
 
-	result, err := cmd.CombinedOutput()
+func (project *pbxProject) removeGroup(parentId string, groupId string) {
+	project.removeFromArray(parentId, "children", groupId)
+	delete(project.objects, groupId)
+}
+
+func (project *pbxProject) findFile(groupId string, filePath string) string {
+	for _, childId := range arrayValue(project.object(groupId), "children") {
+		if stringValue(project.object(childId), "isa") == "PBXFileReference" && project.realPath(childId) == filepath.Clean(filePath) {
+			return childId
+		}
+	}
+	return ""
+}
+
+// addFile adds a file reference to the group, the reference path is stored relative to the path of the group.
+func (project *pbxProject) addFile(groupId string, filePath string) string {
+	relativePath, err := filepath.Rel(project.realPath(groupId), filePath)
 	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Cannot find the xcodeproj gem: %v\n", err.Error()))
-		os.Exit(1)
+		relativePath = filePath
 	}
+	relativePath = filepath.ToSlash(relativePath)
 
-	if !strings.Contains(string(result), "xcodeproj") {
-		os.Stderr.WriteString(fmt.Sprintln("ERROR: The required gem 'xcodeproj' is not installed. Install it using: 'gem install xcodeproj'\n In case you are using the OS X System ruby use: 'sudo gem install xcodeproj'"))
-		os.Exit(1)
+	fileId := generatePbxObjectId(project.objects)
+	fileReference := map[string]interface{}{
+		"isa":               "PBXFileReference",
+		"fileEncoding":      "4",
+		"lastKnownFileType": pbxFileType(filePath),
+		"path":              relativePath,
+		"sourceTree":        "<group>",
+	}
+	if strings.Contains(relativePath, "/") {
+		fileReference["name"] = path.Base(relativePath)
 	}
+	project.objects[fileId] = fileReference
+	project.appendToArray(groupId, "children", fileId)
+
+	return fileId
 }
 
-func startCmd(cmd *exec.Cmd) {
-	outByte, err := cmd.CombinedOutput()
+// removeFile removes every reference to the file from the group, together with the build files that include it in a target.
+func (project *pbxProject) removeFile(groupId string, filePath string) (removed bool) {
+	for {
+		fileId := project.findFile(groupId, filePath)
+		if fileId == "" {
+			return
+		}
 
-	if len(outByte) > 0 {
-		fmt.Printf("%v\n", string(outByte))
+		for id := range project.objects {
+			object := project.object(id)
+			if stringValue(object, "isa") == "PBXBuildFile" && stringValue(object, "fileRef") == fileId {
+				project.removeBuildFile(id)
+			}
+		}
+		project.removeFromArray(groupId, "children", fileId)
+		delete(project.objects, fileId)
+		removed = true
 	}
-	if err != nil {
-		os.Stderr.WriteString(fmt.Sprintf("ERROR: Could not modify Xcode project: %v\n", err.Error()))
-		os.Exit(1)
+}
+
+func (project *pbxProject) removeBuildFile(buildFileId string) {
+	for id := range project.objects {
+		if _, isPhase := pbxDefaultPhaseNames[stringValue(project.object(id), "isa")]; isPhase {
+			project.removeFromArray(id, "files", buildFileId)
+		}
+	}
+	delete(project.objects, buildFileId)
+}
+
+// addFileToTarget adds the file to the build phase of the target that matches its type, sources are compiled, headers are added to
+// the headers phase and everything else is copied as a resource.
+func (project *pbxProject) addFileToTarget(targetId string, fileId string) string {
+	phaseType := "PBXResourcesBuildPhase"
+	extension := strings.ToLower(path.Ext(stringValue(project.object(fileId), "path")))
+	if _, isSource := pbxSourceFileExtensions[extension]; isSource {
+		phaseType = "PBXSourcesBuildPhase"
+	} else if _, isHeader := pbxHeaderFileExtensions[extension]; isHeader {
+		phaseType = "PBXHeadersBuildPhase"
+	}
+
+	phaseId := project.buildPhase(targetId, phaseType)
+	for _, buildFileId := range arrayValue(project.object(phaseId), "files") {
+		if stringValue(project.object(buildFileId), "fileRef") == fileId {
+			return phaseType
+		}
+	}
+
+	buildFileId := generatePbxObjectId(project.objects)
+	project.objects[buildFileId] = map[string]interface{}{
+		"isa":     "PBXBuildFile",
+		"fileRef": fileId,
+	}
+	project.appendToArray(phaseId, "files", buildFileId)
+
+	return phaseType
+}
+
+func (project *pbxProject) buildPhase(targetId string, phaseType string) string {
+	for _, phaseId := range arrayValue(project.object(targetId), "buildPhases") {
+		if stringValue(project.object(phaseId), "isa") == phaseType {
+			return phaseId
+		}
+	}
+
+	phaseId := generatePbxObjectId(project.objects)
+	project.objects[phaseId] = map[string]interface{}{
+		"isa":                                phaseType,
+		"buildActionMask":                    "2147483647",
+		"files":                              []interface{}{},
+		"runOnlyForDeploymentPostprocessing": "0",
+	}
+	project.appendToArray(targetId, "buildPhases", phaseId)
+
+	return phaseId
+}
+
+// realPath resolves the absolute path of a group or file reference by following its source tree up to the project directory.
+func (project *pbxProject) realPath(id string) string {
+	projectDir := filepath.Dir(project.path)
+	if projectDirPath := stringValue(project.rootObject(), "projectDirPath"); projectDirPath != "" {
+		projectDir = filepath.Join(projectDir, projectDirPath)
+	}
+
+	object := project.object(id)
+	objectPath := filepath.FromSlash(stringValue(object, "path"))
+
+	switch stringValue(object, "sourceTree") {
+	case "<absolute>":
+		return filepath.Clean(objectPath)
+	case "SOURCE_ROOT":
+		return filepath.Join(projectDir, objectPath)
+	case "<group>":
+		if id == project.mainGroup() {
+			return filepath.Join(projectDir, objectPath)
+		}
+		parentId := project.parentGroup(id)
+		if parentId == "" {
+			return filepath.Join(projectDir, objectPath)
+		}
+		return filepath.Join(project.realPath(parentId), objectPath)
+	default:
+		return ""
+	}
+}
+
+func (project *pbxProject) parentGroup(childId string) string {
+	for id := range project.objects {
+		object := project.object(id)
+		isa := stringValue(object, "isa")
+		if isa != "PBXGroup" && isa != "PBXVariantGroup" {
+			continue
+		}
+		for _, candidate := range arrayValue(object, "children") {
+			if candidate == childId {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func (project *pbxProject) appendToArray(id string, key string, value string) {
+	object := project.object(id)
+	object[key] = append(toInterfaceArray(arrayValue(object, key)), value)
+}
+
+func (project *pbxProject) removeFromArray(id string, key string, value string) {
+	object := project.object(id)
+	if object == nil {
+		return
+	}
+	object[key] = toInterfaceArray(removeString(arrayValue(object, key), value))
+}
+
+func pbxFileType(filePath string) string {
+	extension := strings.ToLower(path.Ext(filePath))
+	for _, fileTypes := range []map[string]string{pbxSourceFileExtensions, pbxHeaderFileExtensions, pbxResourceFileExtensions} {
+		if fileType, ok := fileTypes[extension]; ok {
+			return fileType
+		}
 	}
+	return "file"
 }
@@ -0,0 +1,121 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// The Xcode project that contains the app target is the one to configure. When a directory contains several Xcode projects, e.g. after
+// 'pod install' or in a React Native project, the workspace next to them tells which project is the app project.
+
+const podsXcodeProjName = "Pods.xcodeproj"
+
+type xcodeWorkspace struct {
+	FileRefs []xcodeWorkspaceFileRef `xml:"FileRef"`
+	Groups   []xcodeWorkspaceGroup   `xml:"Group"`
+}
+
+type xcodeWorkspaceGroup struct {
+	FileRefs []xcodeWorkspaceFileRef `xml:"FileRef"`
+}
+
+type xcodeWorkspaceFileRef struct {
+	Location string `xml:"location,attr"`
+}
+
+// selectAppXcodeProj selects the app project from several Xcode projects. The Pods project is never the app project, after that the project
+// that is referenced by a workspace is preferred, and finally the project that is named after the app target.
+func selectAppXcodeProj(xcodeProjPaths []string, appTarget string) (string, bool) {
+	var candidates []string
+	for _, xcodeProjPath := range xcodeProjPaths {
+		if filepath.Base(xcodeProjPath) != podsXcodeProjName {
+			candidates = append(candidates, xcodeProjPath)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], true
+	}
+
+	var workspaceProjects []string
+	for _, candidate := range candidates {
+		if isReferencedByXcodeWorkspace(candidate) {
+			workspaceProjects = append(workspaceProjects, candidate)
+		}
+	}
+	if len(workspaceProjects) == 1 {
+		return workspaceProjects[0], true
+	}
+
+	for _, candidate := range candidates {
+		if len(appTarget) > 0 && filepath.Base(candidate) == appTarget+".xcodeproj" {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isReferencedByXcodeWorkspace returns whether a workspace in the same directory as the Xcode project references it.
+func isReferencedByXcodeWorkspace(xcodeProjPath string) bool {
+	projectDir := filepath.Dir(xcodeProjPath)
+	workspacePaths, _ := filepath.Glob(filepath.Join(projectDir, "*.xcworkspace"))
+	for _, workspacePath := range workspacePaths {
+		for _, referencedPath := range xcodeWorkspaceProjects(workspacePath) {
+			if filepath.Clean(referencedPath) == filepath.Clean(xcodeProjPath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xcodeWorkspaceProjects returns the paths of the Xcode projects that are referenced by the workspace.
+func xcodeWorkspaceProjects(workspacePath string) (projectPaths []string) {
+	contents, err := ioutil.ReadFile(filepath.Join(workspacePath, "contents.xcworkspacedata"))
+	if err != nil {
+		return nil
+	}
+	var workspace xcodeWorkspace
+	if err := xml.Unmarshal(contents, &workspace); err != nil {
+		return nil
+	}
+
+	fileRefs := workspace.FileRefs
+	for _, group := range workspace.Groups {
+		fileRefs = append(fileRefs, group.FileRefs...)
+	}
+	for _, fileRef := range fileRefs {
+		// locations are prefixed with the type, e.g. 'group:App.xcodeproj' or 'absolute:/path/to/App.xcodeproj'
+		locationType, location, found := strings.Cut(fileRef.Location, ":")
+		if !found || !strings.HasSuffix(location, ".xcodeproj") {
+			continue
+		}
+		if locationType != "absolute" {
+			location = filepath.Join(filepath.Dir(workspacePath), location)
+		}
+		projectPaths = append(projectPaths, location)
+	}
+	return
+}
+
+func baseNames(filePaths []string) (names []string) {
+	for _, filePath := range filePaths {
+		names = append(names, filepath.Base(filePath))
+	}
+	return
+}
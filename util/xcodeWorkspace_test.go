@@ -0,0 +1,76 @@
+//Copyright 2026 Onegini B.V.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSelectAppXcodeProj(t *testing.T) {
+	iosDir := t.TempDir()
+	appProj := filepath.Join(iosDir, "ExampleApp.xcodeproj")
+	otherProj := filepath.Join(iosDir, "Widgets.xcodeproj")
+	podsProj := filepath.Join(iosDir, podsXcodeProjName)
+
+	if result, found := selectAppXcodeProj([]string{appProj, podsProj}, ""); !found || result != appProj {
+		t.Errorf("Incorrect result, expected the Pods project to be skipped but was '%v'", result)
+	}
+	if result, found := selectAppXcodeProj([]string{appProj, otherProj}, "ExampleApp"); !found || result != appProj {
+		t.Errorf("Incorrect result, expected the project named after the target but was '%v'", result)
+	}
+	if _, found := selectAppXcodeProj([]string{appProj, otherProj}, "Other"); found {
+		t.Errorf("Incorrect result, expected no project to be selected")
+	}
+
+	workspace := `<?xml version="1.0" encoding="UTF-8"?>
+<Workspace
+   version = "1.0">
+   <FileRef
+      location = "group:Widgets.xcodeproj">
+   </FileRef>
+   <FileRef
+      location = "group:Pods/Pods.xcodeproj">
+   </FileRef>
+</Workspace>
+`
+	_ = os.MkdirAll(filepath.Join(iosDir, "ExampleApp.xcworkspace"), os.ModePerm)
+	_ = os.WriteFile(filepath.Join(iosDir, "ExampleApp.xcworkspace", "contents.xcworkspacedata"), []byte(workspace), 0644)
+	if result, found := selectAppXcodeProj([]string{appProj, otherProj}, "ExampleApp"); !found || result != otherProj {
+		t.Errorf("Incorrect result, expected the project in the workspace but was '%v'", result)
+	}
+}
+
+func TestGetIosXcodeProjPathWithMultipleProjects(t *testing.T) {
+	appDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(appDir, "ios", "ExampleApp.xcodeproj"), os.ModePerm)
+	_ = os.MkdirAll(filepath.Join(appDir, "ios", "Widgets.xcodeproj"), os.ModePerm)
+	config := &Config{AppDir: appDir, AppTarget: "ExampleApp", ConfigureForReactNative: true}
+
+	result, err := config.getIosXcodeProjPath()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != filepath.Join(appDir, "ios", "ExampleApp.xcodeproj") {
+		t.Errorf("Incorrect result, Xcode project path was '%v'", result)
+	}
+
+	config.AppTarget = "Other"
+	if _, err := config.getIosXcodeProjPath(); !errors.Is(err, ErrMultipleXcodeProjects) {
+		t.Errorf("Incorrect result, expected a multiple Xcode projects error but was: %v", err)
+	}
+}